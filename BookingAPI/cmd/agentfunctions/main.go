@@ -0,0 +1,73 @@
+// Command agentfunctions writes the function details of the Bedrock agent
+// action group, derived from the operations of api.yaml, so that the action
+// group may be defined with them instead of the OpenAPI schema, and the
+// OpenAPI schema of the action group itself. The agent function serves both.
+package main
+
+import (
+	"booking/internal/transport"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// defaultMaxParameters is the default Bedrock quota of parameters per
+// function. The functions with more are only accepted once it is raised.
+const defaultMaxParameters = 5
+
+// functionSchema is the functionSchema of the action group.
+type functionSchema struct {
+	Functions []transport.AgentFunction `json:"functions"`
+}
+
+func main() {
+	output := flag.String("o", "", "file to write the function schema to, the standard output if not set")
+	specFile := flag.String("spec", "api.yaml", "OpenAPI specification of the API")
+	schemaOutput := flag.String("schema", "", "file to write the OpenAPI schema of the action group to, if set")
+	maxParameters := flag.Int("max-parameters", defaultMaxParameters,
+		"quota of parameters per function of the account, the functions over it are reported")
+	flag.Parse()
+
+	spec, err := os.ReadFile(*specFile)
+	if err != nil {
+		log.Fatalf("unable to read the API specification: %v", err)
+	}
+
+	if *schemaOutput != "" {
+		schema, err := agentSchema(spec)
+		if err != nil {
+			log.Fatalf("unable to derive the action group schema: %v", err)
+		}
+		if err := os.WriteFile(*schemaOutput, schema, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	document, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		log.Fatalf("unable to load the API specification: %v", err)
+	}
+	functions := transport.AgentFunctions(document)
+	for _, function := range functions {
+		if len(function.Parameters) > *maxParameters {
+			log.Printf("warning: %s has %d parameters, over the quota of %d per function",
+				function.Name, len(function.Parameters), *maxParameters)
+		}
+	}
+	data, err := json.MarshalIndent(functionSchema{Functions: functions}, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	data = append(data, '\n')
+
+	if *output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
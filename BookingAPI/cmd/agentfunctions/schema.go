@@ -0,0 +1,92 @@
+package main
+
+import (
+	"booking/internal/transport"
+	"bytes"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// agentSchema returns the OpenAPI schema of the action group: api.yaml
+// without the admin operations and the x- extensions, which are about the
+// deployment of the API. The order of the keys and the comments are kept.
+func agentSchema(spec []byte) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(spec, &document); err != nil {
+		return nil, err
+	}
+	root := document.Content[0]
+	removeExtensions(root)
+	if paths := mapValue(root, "paths"); paths != nil {
+		removeAdminOperations(paths)
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// removeExtensions removes the x- keys of the mappings, recursively.
+func removeExtensions(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		removeKeys(node, func(key string, _ *yaml.Node) bool {
+			return strings.HasPrefix(key, "x-")
+		})
+	}
+	for _, child := range node.Content {
+		removeExtensions(child)
+	}
+}
+
+// removeAdminOperations removes the operations tagged admin and the paths
+// left without operations.
+func removeAdminOperations(paths *yaml.Node) {
+	removeKeys(paths, func(_ string, pathItem *yaml.Node) bool {
+		removeKeys(pathItem, func(_ string, operation *yaml.Node) bool {
+			tags := mapValue(operation, "tags")
+			return tags != nil && slices.ContainsFunc(tags.Content, func(tag *yaml.Node) bool {
+				return tag.Value == transport.AdminTag
+			})
+		})
+		for i := 0; i < len(pathItem.Content); i += 2 {
+			if pathItem.Content[i].Value != "parameters" {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// removeKeys removes the entries of the mapping the function matches.
+func removeKeys(mapping *yaml.Node, remove func(key string, value *yaml.Node) bool) {
+	if mapping.Kind != yaml.MappingNode {
+		return
+	}
+	kept := mapping.Content[:0]
+	for i := 0; i < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if !remove(key.Value, value) {
+			kept = append(kept, key, value)
+		}
+	}
+	mapping.Content = kept
+}
+
+// mapValue returns the value of the key of the mapping, nil if there is none.
+func mapValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"booking/internal/app"
+	"booking/internal/service/bookings"
+	"booking/internal/service/dates"
+	"booking/internal/service/drafts"
+	"booking/internal/service/properties"
+	"booking/internal/transport"
+	"context"
+)
+
+func main() {
+	config, stores := app.Bootstrap(context.Background())
+
+	// the agent calls all the operations but the admin ones, unlike the
+	// functions behind API Gateway, each serving one
+	bookingsService := bookings.NewService(stores.Bookings, stores.Blocks, stores.Promotions, stores.Properties)
+	server := transport.NewServer(transport.Services{
+		Properties: properties.NewService(stores.Properties, bookingsService),
+		Bookings:   bookingsService,
+		Drafts:     drafts.NewService(stores.Drafts, stores.Properties, bookingsService),
+		Dates:      dates.NewService(stores.Properties),
+	})
+
+	transport.StartAgent(config, server)
+}
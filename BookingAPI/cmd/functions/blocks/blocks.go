@@ -0,0 +1,17 @@
+package main
+
+import (
+	"booking/internal/app"
+	"booking/internal/service/blocks"
+	"booking/internal/transport"
+	"context"
+)
+
+func main() {
+	config, stores := app.Bootstrap(context.Background())
+
+	service := blocks.NewService(stores.Blocks, stores.Properties)
+	server := transport.NewServer(transport.Services{Blocks: service})
+
+	transport.Start(config, server)
+}
@@ -1,61 +1,17 @@
 package main
 
 import (
-	"context"
-	"net/http"
-
-	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-lambda-go/lambda"
-
-	"booking/configuration"
-	"booking/internal/database"
-	"booking/internal/domain"
+	"booking/internal/app"
 	"booking/internal/service/bookings"
 	"booking/internal/transport"
-
-	"github.com/google/uuid"
+	"context"
 )
 
-// aliasing the types to keep lines short
-type Request = events.APIGatewayProxyRequest
-type Response = events.APIGatewayProxyResponse
-
-// setting up the services
-var config = configuration.New()
-var bookingsStore = database.NewBookingsStore(config)
-var propertiesStore = database.NewPropertiesStore(config)
-var service = bookings.NewService(bookingsStore, propertiesStore)
-
-func handler(ctx context.Context, request Request) (*Response, error) {
-	bookingIdParam, ok := request.PathParameters["bookingId"]
-	if !ok {
-		return transport.Response(http.StatusBadRequest,
-			transport.ErrorBody{"No booking id found"})
-	}
-	if bookingIdParam == "" {
-		return transport.Response(http.StatusBadRequest,
-			transport.ErrorBody{"Empty booking id"})
-	}
+func main() {
+	config, stores := app.Bootstrap(context.Background())
 
-	bookingId, err := uuid.Parse(bookingIdParam)
-	if err != nil {
-		return transport.Response(http.StatusBadRequest,
-			transport.ErrorBody{"Invalid booking id"})
-	}
+	service := bookings.NewService(stores.Bookings, stores.Blocks, stores.Promotions, stores.Properties)
+	server := transport.NewServer(transport.Services{Bookings: service})
 
-	err = service.Cancel(ctx, bookingId)
-	if err != nil {
-		switch err {
-		case domain.ErrBookingNotFound:
-			return transport.Response(http.StatusNotFound,
-				transport.ErrorBody{"Booking not found"})
-		default:
-			return nil, err
-		}
-	}
-	return transport.Response(http.StatusNoContent, nil)
-}
-
-func main() {
-	lambda.Start(handler)
+	transport.Start(config, server)
 }
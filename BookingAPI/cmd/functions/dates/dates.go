@@ -0,0 +1,17 @@
+package main
+
+import (
+	"booking/internal/app"
+	"booking/internal/service/dates"
+	"booking/internal/transport"
+	"context"
+)
+
+func main() {
+	config, stores := app.Bootstrap(context.Background())
+
+	// the properties are read for their time zones only
+	server := transport.NewServer(transport.Services{Dates: dates.NewService(stores.Properties)})
+
+	transport.Start(config, server)
+}
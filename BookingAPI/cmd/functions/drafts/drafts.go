@@ -0,0 +1,20 @@
+package main
+
+import (
+	"booking/internal/app"
+	"booking/internal/service/bookings"
+	"booking/internal/service/drafts"
+	"booking/internal/transport"
+	"context"
+)
+
+func main() {
+	config, stores := app.Bootstrap(context.Background())
+
+	// the drafts are submitted with the checks of the booking function
+	bookingsService := bookings.NewService(stores.Bookings, stores.Blocks, stores.Promotions, stores.Properties)
+	service := drafts.NewService(stores.Drafts, stores.Properties, bookingsService)
+	server := transport.NewServer(transport.Services{Drafts: service})
+
+	transport.Start(config, server)
+}
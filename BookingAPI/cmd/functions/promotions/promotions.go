@@ -0,0 +1,17 @@
+package main
+
+import (
+	"booking/internal/app"
+	"booking/internal/service/promotions"
+	"booking/internal/transport"
+	"context"
+)
+
+func main() {
+	config, stores := app.Bootstrap(context.Background())
+
+	service := promotions.NewService(stores.Promotions)
+	server := transport.NewServer(transport.Services{Promotions: service})
+
+	transport.Start(config, server)
+}
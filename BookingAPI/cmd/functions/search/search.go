@@ -1,61 +1,20 @@
 package main
 
 import (
-	"booking/configuration"
-	"booking/internal/database"
-	"booking/internal/domain"
+	"booking/internal/app"
+	"booking/internal/service/bookings"
 	"booking/internal/service/properties"
 	"booking/internal/transport"
 	"context"
-	"encoding/json"
-	"net/http"
-
-	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-lambda-go/lambda"
 )
 
-// aliasing the types to keep lines short
-type Request = events.APIGatewayProxyRequest
-type Response = events.APIGatewayProxyResponse
-
-// setting up the services
-var config = configuration.New()
-var store = database.NewPropertiesStore(config)
-var service = properties.NewService(store)
-
-func handler(ctx context.Context, request Request) (*Response, error) {
-	body := request.Body
-	if body == "" {
-		return transport.Response(http.StatusBadRequest,
-			transport.ErrorBody{"Empty body"})
-	}
-
-	options := new(domain.SearchOptions)
-	err := json.Unmarshal([]byte(body), options)
-	if err != nil {
-		return transport.Response(http.StatusBadRequest,
-			transport.ErrorBody{"Invalid JSON"})
-	}
-
-	if options.City == nil && options.Country == nil {
-		return transport.Response(http.StatusBadRequest,
-			transport.ErrorBody{"Missing city or country"})
-	}
-
-	properties, err := service.Search(ctx, *options)
-	if err != nil {
-		switch err {
-		case domain.ErrPropertyNotFound:
-			return transport.Response(http.StatusNotFound,
-				transport.ErrorBody{"Property not found"})
-		default:
-			return nil, err
-		}
-	}
+func main() {
+	config, stores := app.Bootstrap(context.Background())
 
-	return transport.Response(http.StatusOK, properties)
-}
+	// the bookings service filters the properties by the dates of the stay
+	bookingsService := bookings.NewService(stores.Bookings, stores.Blocks, stores.Promotions, stores.Properties)
+	service := properties.NewService(stores.Properties, bookingsService)
+	server := transport.NewServer(transport.Services{Properties: service})
 
-func main() {
-	lambda.Start(handler)
+	transport.Start(config, server)
 }
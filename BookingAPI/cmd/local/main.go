@@ -0,0 +1,96 @@
+// Command local serves the whole API over HTTP from in-memory stores, or
+// a SQLite database, seeded with the properties of a JSON file, to run it
+// without DynamoDB and SAM.
+package main
+
+import (
+	"booking/configuration"
+	"booking/internal/database/backend"
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"booking/internal/metrics"
+	"booking/internal/service/blocks"
+	"booking/internal/service/bookings"
+	"booking/internal/service/dates"
+	"booking/internal/service/drafts"
+	"booking/internal/service/promotions"
+	"booking/internal/service/properties"
+	"booking/internal/tracing"
+	"booking/internal/transport"
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	seed := flag.String("properties", "local/properties.json", "JSON file with the properties to serve")
+	sqlite := flag.String("sqlite", "", "SQLite database file to keep the data in, in memory if not set")
+	traces := flag.String("traces", "none", "exporter of the spans: none, otlp or stdout")
+	metricsExporter := flag.String("metrics", "none", "exporter of the metrics: none or emf")
+	adminKey := flag.String("admin-key", "", "key of the admin operations, disabled if not set")
+	flag.Parse()
+
+	slog.SetDefault(logging.New(os.Stdout, "debug"))
+
+	data, err := os.ReadFile(*seed)
+	if err != nil {
+		log.Fatalf("unable to read properties: %v", err)
+	}
+	var seeded []domain.Property
+	if err := json.Unmarshal(data, &seeded); err != nil {
+		log.Fatalf("unable to parse properties: %v", err)
+	}
+
+	config := configuration.Default()
+	config.ValidationMode = "strict"
+	config.LogLevel = "debug"
+	config.TracesExporter = *traces
+	config.MetricsExporter = *metricsExporter
+	config.AdminAPIKey = *adminKey
+	config.StorageBackend = configuration.BackendMemory
+	if *sqlite != "" {
+		config.StorageBackend = configuration.BackendSQL
+		config.DatabaseDriver = "sqlite"
+		config.DatabaseURL = *sqlite
+	}
+	slog.Info("configuration loaded", "config", config)
+
+	ctx := context.Background()
+	shutdown, err := tracing.Setup(ctx, config.TracesExporter)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer shutdown(ctx)
+	if err := metrics.Setup(config.MetricsExporter, config.MetricsNamespace); err != nil {
+		log.Fatal(err)
+	}
+
+	stores, err := backend.New(ctx, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, property := range seeded {
+		if err := stores.Properties.PutProperty(ctx, property); err != nil {
+			log.Fatalf("unable to seed properties: %v", err)
+		}
+	}
+
+	bookingsService := bookings.NewService(stores.Bookings, stores.Blocks, stores.Promotions, stores.Properties)
+	server := transport.NewServer(transport.Services{
+		Properties: properties.NewService(stores.Properties, bookingsService),
+		Bookings:   bookingsService,
+		Drafts:     drafts.NewService(stores.Drafts, stores.Properties, bookingsService),
+		Dates:      dates.NewService(stores.Properties),
+		Blocks:     blocks.NewService(stores.Blocks, stores.Properties),
+		Promotions: promotions.NewService(stores.Promotions),
+	})
+	handler := transport.NewHTTPHandler(server, transport.Middlewares(config)...)
+
+	slog.Info("serving", "properties", len(seeded), "addr", *addr)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
@@ -4,41 +4,157 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 )
 
 const (
+	EnvConfigFile          = "CONFIG_FILE"
 	EnvPropertiesTableName = "PROPERTIES_TABLE_NAME"
 	EnvBookingsTableName   = "BOOKINGS_TABLE_NAME"
+	EnvDraftsTableName     = "DRAFTS_TABLE_NAME"
+	EnvPromotionsTableName = "PROMOTIONS_TABLE_NAME"
+	EnvDynamoDBEndpoint    = "DYNAMODB_ENDPOINT"
+	EnvValidationMode      = "OPENAPI_VALIDATION_MODE"
+	EnvAllowedOrigins      = "CORS_ALLOWED_ORIGINS"
+	EnvAPIKey              = "API_KEY"
+	EnvAdminAPIKey         = "ADMIN_API_KEY"
+	EnvStorageBackend      = "STORAGE_BACKEND"
+	EnvDatabaseDriver      = "DATABASE_DRIVER"
+	EnvDatabaseURL         = "DATABASE_URL"
+	EnvLogLevel            = "LOG_LEVEL"
+	EnvFeatures            = "FEATURE_FLAGS"
+	EnvTracesExporter      = "TRACES_EXPORTER"
+	EnvMetricsExporter     = "METRICS_EXPORTER"
+	EnvMetricsNamespace    = "METRICS_NAMESPACE"
+
+	DefaultValidationMode   = "log"
+	DefaultAllowedOrigins   = "*"
+	DefaultStorageBackend   = BackendDynamoDB
+	DefaultDatabaseDriver   = "sqlite"
+	DefaultLogLevel         = "info"
+	DefaultTracesExporter   = "none"
+	DefaultMetricsExporter  = "emf"
+	DefaultMetricsNamespace = "BookingAPI"
+)
+
+// The storage backends the stores may be kept in.
+const (
+	BackendDynamoDB = "dynamodb"
+	BackendSQL      = "sql"
+	BackendMemory   = "memory"
 )
 
+// Config is the configuration of the functions. The fields are set, from
+// the lowest precedence, by Default, the file named by CONFIG_FILE and the
+// environment variables.
 type Config struct {
-	AwsConfig           aws.Config
-	PropertiesTableName string
-	BookingsTableName   string
+	AwsConfig           aws.Config `json:"-" yaml:"-"`
+	PropertiesTableName string     `json:"propertiesTableName" yaml:"propertiesTableName"`
+	BookingsTableName   string     `json:"bookingsTableName" yaml:"bookingsTableName"`
+	DraftsTableName     string     `json:"draftsTableName" yaml:"draftsTableName"`
+	PromotionsTableName string     `json:"promotionsTableName" yaml:"promotionsTableName"`
+	// DynamoDBEndpoint overrides the endpoint of DynamoDB, e.g. to use
+	// a local instance.
+	DynamoDBEndpoint string   `json:"dynamoDBEndpoint" yaml:"dynamoDBEndpoint"`
+	ValidationMode   string   `json:"validationMode" yaml:"validationMode"`
+	AllowedOrigins   []string `json:"allowedOrigins" yaml:"allowedOrigins"`
+	APIKey           string   `json:"apiKey" yaml:"apiKey"`
+	// AdminAPIKey authenticates the admin operations, which are disabled
+	// without it.
+	AdminAPIKey    string `json:"adminApiKey" yaml:"adminApiKey"`
+	StorageBackend string `json:"storageBackend" yaml:"storageBackend"`
+	// DatabaseDriver and DatabaseURL select the database of the SQL backend.
+	DatabaseDriver string          `json:"databaseDriver" yaml:"databaseDriver"`
+	DatabaseURL    string          `json:"databaseURL" yaml:"databaseURL"`
+	LogLevel       string          `json:"logLevel" yaml:"logLevel"`
+	Features       map[string]bool `json:"features" yaml:"features"`
+	// TracesExporter is where the spans go: none, otlp or stdout.
+	TracesExporter string `json:"tracesExporter" yaml:"tracesExporter"`
+	// MetricsExporter is where the business metrics go: none or emf, the
+	// CloudWatch embedded metric format.
+	MetricsExporter  string `json:"metricsExporter" yaml:"metricsExporter"`
+	MetricsNamespace string `json:"metricsNamespace" yaml:"metricsNamespace"`
+}
+
+// Default returns the configuration used when nothing else is set.
+func Default() Config {
+	return Config{
+		ValidationMode:   DefaultValidationMode,
+		AllowedOrigins:   []string{DefaultAllowedOrigins},
+		StorageBackend:   DefaultStorageBackend,
+		DatabaseDriver:   DefaultDatabaseDriver,
+		LogLevel:         DefaultLogLevel,
+		Features:         defaultFeatures(),
+		TracesExporter:   DefaultTracesExporter,
+		MetricsExporter:  DefaultMetricsExporter,
+		MetricsNamespace: DefaultMetricsNamespace,
+	}
 }
 
-func New() Config {
-	cfg, err := config.LoadDefaultConfig(context.TODO())
-	if err != nil {
-		panic(fmt.Errorf("unable to load AWS config: %w", err))
+// Load reads the configuration and validates it. The AWS configuration is
+// loaded only for the DynamoDB backend.
+func Load(ctx context.Context) (Config, error) {
+	config := Default()
+
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		if err := config.loadFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := config.loadEnv(); err != nil {
+		return Config{}, err
+	}
+	if err := config.Validate(); err != nil {
+		return Config{}, err
 	}
 
-	propertiesTableName := os.Getenv(EnvPropertiesTableName)
-	if propertiesTableName == "" {
-		panic(fmt.Errorf("%s is not set", EnvPropertiesTableName))
+	if config.StorageBackend == BackendDynamoDB {
+		awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return Config{}, fmt.Errorf("unable to load AWS config: %w", err)
+		}
+		if config.DynamoDBEndpoint != "" {
+			awsConfig.BaseEndpoint = aws.String(config.DynamoDBEndpoint)
+		}
+		config.AwsConfig = awsConfig
 	}
+	return config, nil
+}
 
-	bookingsTableName := os.Getenv(EnvBookingsTableName)
-	if bookingsTableName == "" {
-		panic(fmt.Errorf("%s is not set", EnvBookingsTableName))
+// loadEnv overrides the fields with the environment variables that are set.
+func (config *Config) loadEnv() error {
+	setFromEnv(&config.PropertiesTableName, EnvPropertiesTableName)
+	setFromEnv(&config.BookingsTableName, EnvBookingsTableName)
+	setFromEnv(&config.DraftsTableName, EnvDraftsTableName)
+	setFromEnv(&config.PromotionsTableName, EnvPromotionsTableName)
+	setFromEnv(&config.DynamoDBEndpoint, EnvDynamoDBEndpoint)
+	setFromEnv(&config.ValidationMode, EnvValidationMode)
+	setFromEnv(&config.APIKey, EnvAPIKey)
+	setFromEnv(&config.AdminAPIKey, EnvAdminAPIKey)
+	setFromEnv(&config.StorageBackend, EnvStorageBackend)
+	setFromEnv(&config.DatabaseDriver, EnvDatabaseDriver)
+	setFromEnv(&config.DatabaseURL, EnvDatabaseURL)
+	setFromEnv(&config.LogLevel, EnvLogLevel)
+	setFromEnv(&config.TracesExporter, EnvTracesExporter)
+	setFromEnv(&config.MetricsExporter, EnvMetricsExporter)
+	setFromEnv(&config.MetricsNamespace, EnvMetricsNamespace)
+
+	if allowedOrigins := os.Getenv(EnvAllowedOrigins); allowedOrigins != "" {
+		config.AllowedOrigins = strings.Split(allowedOrigins, ",")
+	}
+	if features := os.Getenv(EnvFeatures); features != "" {
+		if err := config.setFeatures(features); err != nil {
+			return fmt.Errorf("%s: %w", EnvFeatures, err)
+		}
 	}
+	return nil
+}
 
-	return Config{
-		AwsConfig:           cfg,
-		PropertiesTableName: propertiesTableName,
-		BookingsTableName:   bookingsTableName,
+func setFromEnv(field *string, name string) {
+	if value := os.Getenv(name); value != "" {
+		*field = value
 	}
 }
@@ -0,0 +1,47 @@
+package configuration
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+// FeatureServerTiming reports the duration of the requests in the
+// Server-Timing header of the responses.
+const FeatureServerTiming = "server_timing"
+
+// knownFeatures are the feature flags with their default state.
+var knownFeatures = map[string]bool{
+	FeatureServerTiming: true,
+}
+
+func defaultFeatures() map[string]bool {
+	return maps.Clone(knownFeatures)
+}
+
+// Enabled tells whether the feature flag is on.
+func (config Config) Enabled(feature string) bool {
+	return config.Features[feature]
+}
+
+// setFeatures sets the flags from a comma separated list, where a name
+// alone turns the feature on and name=<bool> sets it explicitly.
+func (config *Config) setFeatures(value string) error {
+	if config.Features == nil {
+		config.Features = map[string]bool{}
+	}
+
+	for _, flag := range strings.Split(value, ",") {
+		name, state, found := strings.Cut(strings.TrimSpace(flag), "=")
+		enabled := true
+		if found {
+			var err error
+			if enabled, err = strconv.ParseBool(state); err != nil {
+				return fmt.Errorf("flag %s is neither true nor false", name)
+			}
+		}
+		config.Features[name] = enabled
+	}
+	return nil
+}
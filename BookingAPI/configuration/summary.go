@@ -0,0 +1,73 @@
+package configuration
+
+import (
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// keywordPassword matches the password of a key=value connection string.
+var keywordPassword = regexp.MustCompile(`password=\S+`)
+
+// LogValue logs a summary of the configuration at startup. The API keys and
+// the database password are left out.
+func (config Config) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("storage_backend", config.StorageBackend)}
+	switch config.StorageBackend {
+	case BackendDynamoDB:
+		attrs = append(attrs,
+			slog.String("properties_table", config.PropertiesTableName),
+			slog.String("bookings_table", config.BookingsTableName),
+			slog.String("drafts_table", config.DraftsTableName),
+			slog.String("promotions_table", config.PromotionsTableName),
+			slog.String("dynamodb_endpoint", orDefault(config.DynamoDBEndpoint)))
+	case BackendSQL:
+		attrs = append(attrs,
+			slog.String("database_driver", config.DatabaseDriver),
+			slog.String("database", redact(config.DatabaseURL)))
+	}
+
+	apiKey := "not set, requests are not authenticated"
+	if config.APIKey != "" {
+		apiKey = "set"
+	}
+	adminAPIKey := "not set, admin operations are disabled"
+	if config.AdminAPIKey != "" {
+		adminAPIKey = "set"
+	}
+
+	var features []string
+	for _, feature := range sortedKeys(config.Features) {
+		state := "off"
+		if config.Features[feature] {
+			state = "on"
+		}
+		features = append(features, feature+"="+state)
+	}
+
+	return slog.GroupValue(append(attrs,
+		slog.String("validation_mode", config.ValidationMode),
+		slog.String("allowed_origins", strings.Join(config.AllowedOrigins, ", ")),
+		slog.String("api_key", apiKey),
+		slog.String("admin_api_key", adminAPIKey),
+		slog.String("log_level", config.LogLevel),
+		slog.String("traces_exporter", config.TracesExporter),
+		slog.String("metrics_exporter", config.MetricsExporter),
+		slog.String("features", orDefault(strings.Join(features, ", "))))...)
+}
+
+func orDefault(value string) string {
+	if value == "" {
+		return "(default)"
+	}
+	return value
+}
+
+// redact hides the password of a database URL or connection string.
+func redact(dsn string) string {
+	if parsed, err := url.Parse(dsn); err == nil && parsed.User != nil {
+		return parsed.Redacted()
+	}
+	return keywordPassword.ReplaceAllString(dsn, "password=xxxxx")
+}
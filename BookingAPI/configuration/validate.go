@@ -0,0 +1,124 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	storageBackends  = []string{BackendDynamoDB, BackendSQL, BackendMemory}
+	databaseDrivers  = []string{"sqlite", "postgres"}
+	validationModes  = []string{"off", "log", "strict"}
+	logLevels        = []string{"debug", "info", "warn", "error"}
+	tracesExporters  = []string{"none", "otlp", "stdout"}
+	metricsExporters = []string{"none", "emf"}
+)
+
+// tableName matches the names DynamoDB accepts for tables.
+var tableName = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,255}$`)
+
+// Validate checks all the fields, reporting every invalid one at once.
+func (config Config) Validate() error {
+	var errs []error
+	invalid := func(field, env, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s (%s): %s", field, env, fmt.Sprintf(format, args...)))
+	}
+
+	if !slices.Contains(storageBackends, config.StorageBackend) {
+		invalid("storageBackend", EnvStorageBackend, "%q is not one of %s",
+			config.StorageBackend, strings.Join(storageBackends, ", "))
+	}
+
+	switch config.StorageBackend {
+	case BackendDynamoDB:
+		if !tableName.MatchString(config.PropertiesTableName) {
+			invalid("propertiesTableName", EnvPropertiesTableName, "%q is not a valid table name",
+				config.PropertiesTableName)
+		}
+		if !tableName.MatchString(config.BookingsTableName) {
+			invalid("bookingsTableName", EnvBookingsTableName, "%q is not a valid table name",
+				config.BookingsTableName)
+		}
+		if !tableName.MatchString(config.DraftsTableName) {
+			invalid("draftsTableName", EnvDraftsTableName, "%q is not a valid table name",
+				config.DraftsTableName)
+		}
+		if !tableName.MatchString(config.PromotionsTableName) {
+			invalid("promotionsTableName", EnvPromotionsTableName, "%q is not a valid table name",
+				config.PromotionsTableName)
+		}
+		if config.DynamoDBEndpoint != "" && !isHTTPURL(config.DynamoDBEndpoint) {
+			invalid("dynamoDBEndpoint", EnvDynamoDBEndpoint, "%q is not an http(s) URL",
+				config.DynamoDBEndpoint)
+		}
+	case BackendSQL:
+		if !slices.Contains(databaseDrivers, config.DatabaseDriver) {
+			invalid("databaseDriver", EnvDatabaseDriver, "%q is not one of %s",
+				config.DatabaseDriver, strings.Join(databaseDrivers, ", "))
+		}
+		if config.DatabaseURL == "" {
+			invalid("databaseURL", EnvDatabaseURL, "is required by the %s backend", BackendSQL)
+		}
+	}
+
+	if !slices.Contains(validationModes, config.ValidationMode) {
+		invalid("validationMode", EnvValidationMode, "%q is not one of %s",
+			config.ValidationMode, strings.Join(validationModes, ", "))
+	}
+
+	if len(config.AllowedOrigins) == 0 {
+		invalid("allowedOrigins", EnvAllowedOrigins, "at least one origin is required")
+	}
+	for _, origin := range config.AllowedOrigins {
+		if origin != "*" && !isHTTPURL(origin) {
+			invalid("allowedOrigins", EnvAllowedOrigins, "%q is neither * nor an http(s) origin", origin)
+		}
+	}
+
+	if !slices.Contains(logLevels, config.LogLevel) {
+		invalid("logLevel", EnvLogLevel, "%q is not one of %s",
+			config.LogLevel, strings.Join(logLevels, ", "))
+	}
+
+	if !slices.Contains(tracesExporters, config.TracesExporter) {
+		invalid("tracesExporter", EnvTracesExporter, "%q is not one of %s",
+			config.TracesExporter, strings.Join(tracesExporters, ", "))
+	}
+
+	if !slices.Contains(metricsExporters, config.MetricsExporter) {
+		invalid("metricsExporter", EnvMetricsExporter, "%q is not one of %s",
+			config.MetricsExporter, strings.Join(metricsExporters, ", "))
+	}
+	if config.MetricsExporter != "none" && config.MetricsNamespace == "" {
+		invalid("metricsNamespace", EnvMetricsNamespace, "is required by the %s exporter", config.MetricsExporter)
+	}
+
+	for _, feature := range sortedKeys(config.Features) {
+		if _, ok := knownFeatures[feature]; !ok {
+			invalid("features", EnvFeatures, "%q is not a known feature", feature)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func isHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,46 @@
+// Package app sets up what every function needs before it builds its
+// services: the configuration, the logger, the tracing, the metrics and
+// the stores.
+package app
+
+import (
+	"booking/configuration"
+	"booking/internal/database/backend"
+	"booking/internal/logging"
+	"booking/internal/metrics"
+	"booking/internal/tracing"
+	"context"
+	"log"
+	"log/slog"
+	"os"
+)
+
+// Bootstrap loads the configuration, sets up the default logger, the
+// tracing and the metrics, and opens the stores. A function cannot serve
+// without them, so the process exits if any of them fails.
+func Bootstrap(ctx context.Context) (configuration.Config, backend.Stores) {
+	config, err := configuration.Load(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logging.New(os.Stdout, config.LogLevel))
+	slog.Info("configuration loaded", "config", config)
+
+	// the function is frozen between the invocations, NewHandler flushes
+	// the spans, so there is nothing to shut down
+	if _, err := tracing.Setup(ctx, config.TracesExporter); err != nil {
+		slog.Error("unable to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	if err := metrics.Setup(config.MetricsExporter, config.MetricsNamespace); err != nil {
+		slog.Error("unable to set up metrics", "error", err)
+		os.Exit(1)
+	}
+
+	stores, err := backend.New(ctx, config)
+	if err != nil {
+		slog.Error("unable to open the stores", "error", err)
+		os.Exit(1)
+	}
+	return config, stores
+}
@@ -0,0 +1,68 @@
+// Package backend opens the stores of the storage backend selected by the
+// configuration.
+package backend
+
+import (
+	"booking/configuration"
+	"booking/internal/database"
+	"booking/internal/database/memory"
+	"booking/internal/database/sqldb"
+	"context"
+	"fmt"
+)
+
+// Stores are the stores of one backend. Blocks and Promotions are the same
+// store as Bookings, as the blocks share the nights with the bookings and
+// the bookings redeem the promotions.
+type Stores struct {
+	Bookings   database.BookingsStore
+	Blocks     database.BlocksStore
+	Promotions database.PromotionsStore
+	Properties database.PropertiesStore
+	Drafts     database.DraftsStore
+}
+
+// New opens the stores of config.StorageBackend. The SQL database is
+// migrated to the latest schema before it is used.
+func New(ctx context.Context, config configuration.Config) (Stores, error) {
+	switch config.StorageBackend {
+	case configuration.BackendDynamoDB:
+		bookings := database.NewBookingsStore(config)
+		return Stores{
+			Bookings:   bookings,
+			Blocks:     bookings,
+			Promotions: bookings,
+			Properties: database.NewPropertiesStore(config),
+			Drafts:     database.NewDraftsStore(config),
+		}, nil
+
+	case configuration.BackendSQL:
+		db, err := sqldb.Open(config.DatabaseDriver, config.DatabaseURL)
+		if err != nil {
+			return Stores{}, err
+		}
+		if err := db.Migrate(ctx); err != nil {
+			db.Close()
+			return Stores{}, err
+		}
+		bookings := sqldb.NewBookingsStore(db)
+		return Stores{
+			Bookings:   bookings,
+			Blocks:     bookings,
+			Promotions: bookings,
+			Properties: sqldb.NewPropertiesStore(db),
+			Drafts:     sqldb.NewDraftsStore(db),
+		}, nil
+
+	case configuration.BackendMemory:
+		bookings := memory.NewBookingsStore()
+		return Stores{
+			Bookings:   bookings,
+			Blocks:     bookings,
+			Promotions: bookings,
+			Properties: memory.NewPropertiesStore(),
+			Drafts:     memory.NewDraftsStore(),
+		}, nil
+	}
+	return Stores{}, fmt.Errorf("unknown storage backend %q", config.StorageBackend)
+}
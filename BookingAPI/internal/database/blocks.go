@@ -0,0 +1,108 @@
+package database
+
+import (
+	"booking/internal/domain"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// The blocks are stored in the table of the bookings, keyed apart from them,
+// so that the locks of their nights keep the bookings off the blocked nights
+// and the other way round.
+
+// AddBlock stores the block along with a lock item for every night it
+// spans, holding the property as a whole like AddBooking does for a booking
+// without a unit.
+func (store *bookingsStore) AddBlock(ctx context.Context, block domain.Block) error {
+	nights := NightsBetween(block.StartDate.Time, block.EndDate.Time)
+	if len(nights) > MaxNights {
+		return &Error{Op: opTransact, Table: store.table.tableName, Kind: ErrValidation,
+			Err: fmt.Errorf("a block may span at most %d nights", MaxNights)}
+	}
+
+	wrapped := blockWrapper{
+		Key:        blockKey(block.BlockId.String()),
+		BlockId:    block.BlockId.String(),
+		PropertyId: block.PropertyId,
+		StartDate:  block.StartDate.String(),
+		EndDate:    block.EndDate.String(),
+		Reason:     block.Reason,
+	}
+	item, err := marshalItem(wrapped, store.table)
+	if err != nil {
+		return err
+	}
+	return store.putHoldingNights(ctx, item, wrapped.Key, block.PropertyId, "", nights, nil)
+}
+
+func (store *bookingsStore) GetBlocksForProperty(ctx context.Context, propertyId int) ([]domain.Block, error) {
+	indexName := "PropertyIdIndex"
+	keyConditionExpression := "propertyId = :propertyId"
+	filterExpression := "attribute_exists(blockId)"
+	expressionAttributeValues := map[string]types.AttributeValue{
+		":propertyId": &types.AttributeValueMemberN{Value: strconv.Itoa(propertyId)},
+	}
+
+	wrapped, err := query[blockWrapper](
+		ctx,
+		&indexName,
+		&keyConditionExpression,
+		&filterExpression,
+		expressionAttributeValues,
+		store.table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]domain.Block, len(wrapped))
+	for i, w := range wrapped {
+		blocks[i] = w.asBlock()
+	}
+	return blocks, nil
+}
+
+// RemoveBlock removes the block and releases the locks of its nights.
+func (store *bookingsStore) RemoveBlock(ctx context.Context, blockId string) error {
+	key := blockKey(blockId)
+	wrapped, err := getItem[blockWrapper](
+		ctx,
+		map[string]types.AttributeValue{
+			"bookingId": &types.AttributeValueMemberS{Value: key},
+		},
+		store.table,
+	)
+	if err != nil {
+		return err
+	}
+
+	block := wrapped.asBlock()
+	return store.deleteHoldingNights(ctx, key, block.PropertyId, "",
+		NightsBetween(block.StartDate.Time, block.EndDate.Time), nil)
+}
+
+func blockKey(blockId string) string {
+	return "block#" + blockId
+}
+
+type blockWrapper struct {
+	Key        string `json:"bookingId"`
+	BlockId    string `json:"blockId"`
+	PropertyId int    `json:"propertyId"`
+	StartDate  string `json:"startDate"`
+	EndDate    string `json:"endDate"`
+	Reason     string `json:"reason"`
+}
+
+func (w *blockWrapper) asBlock() domain.Block {
+	block := domain.Block{
+		PropertyId: w.PropertyId,
+		Reason:     w.Reason,
+	}
+	block.BlockId.UnmarshalText([]byte(w.BlockId))
+	block.StartDate.UnmarshalText([]byte(w.StartDate))
+	block.EndDate.UnmarshalText([]byte(w.EndDate))
+	return block
+}
@@ -4,33 +4,107 @@ import (
 	"booking/configuration"
 	"booking/internal/domain"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"strconv"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
+// MaxNights keeps a booking, the locks of its nights and the redemption of
+// its promo code within the 100 items a single DynamoDB transaction can write.
+const MaxNights = 98
+
+// errNotRedeemed reports the release of a redemption of a promotion that is
+// gone or has none.
+var errNotRedeemed = errors.New("promotion not redeemed")
+
 type bookingsStore struct {
-	table *table
+	table      *table
+	promotions *table
 }
 
 func NewBookingsStore(config configuration.Config) *bookingsStore {
 	return &bookingsStore{
-		table: newTable(config.AwsConfig, config.BookingsTableName),
+		table:      newTable(config.AwsConfig, config.BookingsTableName),
+		promotions: newTable(config.AwsConfig, config.PromotionsTableName),
 	}
 }
 
+// AddBooking stores the booking along with a lock item for every night it
+// spans, all in one transaction. The locks are keyed by the property and the
+// night: a booking of the property as a whole holds the lock, as a block
+// does, and a booking of a unit adds the unit to the units of the lock. So a
+// booking overlapping a booking of the same unit, or of the property as a
+// whole, or a block, fails on their condition, as does a booking of the
+// property as a whole overlapping any booking. The locks have no propertyId
+// attribute, which keeps them out of the PropertyIdIndex. A booking with a
+// promo code redeems it in the same transaction.
 func (store *bookingsStore) AddBooking(ctx context.Context, booking domain.Booking) error {
+	nights := Nights(booking)
+	if len(nights) > MaxNights {
+		return &Error{Op: opTransact, Table: store.table.tableName, Kind: ErrValidation,
+			Err: fmt.Errorf("a booking may span at most %d nights", MaxNights)}
+	}
+
 	wrapped := bookingWrapper{
 		Booking:   booking,
 		StartDate: booking.StartDate.String(),
 		EndDate:   booking.EndDate.String(),
 	}
-	return putItem(ctx, wrapped, store.table)
+	item, err := marshalItem(wrapped, store.table)
+	if err != nil {
+		return err
+	}
+	var redemption *types.TransactWriteItem
+	if booking.PromoCode != nil {
+		redemption = store.redemption(*booking.PromoCode)
+	}
+	return store.putHoldingNights(ctx, item, booking.BookingId, booking.PropertyId, UnitKey(booking), nights,
+		redemption)
+}
+
+// putHoldingNights puts the item, keyed by the holder, along with the locks
+// of the nights of the property held by it, as a whole for an empty unit
+// key, and the redemption of a promo code if any.
+func (store *bookingsStore) putHoldingNights(ctx context.Context, item map[string]types.AttributeValue,
+	holder string, propertyId int, unitKey string, nights []time.Time, redemption *types.TransactWriteItem) error {
+
+	items := []types.TransactWriteItem{{
+		Put: &types.Put{
+			TableName:           &store.table.tableName,
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(bookingId)"),
+		},
+	}}
+	for _, night := range nights {
+		items = append(items, store.holdNight(holder, propertyId, unitKey, night))
+	}
+	if redemption != nil {
+		items = append(items, *redemption)
+	}
+
+	err := store.table.transactWriteItems(ctx, items)
+	failed := failedTransactItems(err)
+	switch {
+	case slices.Contains(failed, 0):
+		return &Error{Op: opTransact, Table: store.table.tableName, Kind: ErrConditionalCheckFailed, Err: err}
+	case redemption != nil && slices.Equal(failed, []int{len(items) - 1}):
+		return &Error{Op: opTransact, Table: store.promotions.tableName, Kind: ErrLimitReached, Err: err}
+	case len(failed) > 0:
+		return &Error{Op: opTransact, Table: store.table.tableName, Kind: ErrOverlap, Err: err}
+	}
+	return err
 }
 
 func (store *bookingsStore) GetBookingsForProperty(ctx context.Context, propertyId int) ([]domain.Booking, error) {
 	indexName := "PropertyIdIndex"
 	keyConditionExpression := "propertyId = :propertyId"
+	// the blocks share the index
+	filterExpression := "attribute_not_exists(blockId)"
 	expressionAttributeValues := map[string]types.AttributeValue{
 		":propertyId": &types.AttributeValueMemberN{Value: strconv.Itoa(propertyId)},
 	}
@@ -39,7 +113,7 @@ func (store *bookingsStore) GetBookingsForProperty(ctx context.Context, property
 		ctx,
 		&indexName,
 		&keyConditionExpression,
-		nil,
+		&filterExpression,
 		expressionAttributeValues,
 		store.table,
 	)
@@ -63,19 +137,149 @@ func (store *bookingsStore) GetBooking(ctx context.Context, bookingId string) (*
 	)
 	if err != nil {
 		return nil, err
-	} else if wrapped == nil {
-		return nil, nil
 	}
 
 	booking := wrapped.asBooking()
 	return &booking, nil
 }
 
+// RemoveBooking removes the booking and releases the locks of its nights.
+// The locks are removed only if they are held by the booking or are missing,
+// as for the bookings stored before the locks were introduced. The
+// redemption of its promo code is released in the same transaction, unless
+// the promotion has been removed.
 func (store *bookingsStore) RemoveBooking(ctx context.Context, bookingId string) error {
+	booking, err := store.GetBooking(ctx, bookingId)
+	if err != nil {
+		return err
+	}
+
+	propertyId, unitKey, nights := booking.PropertyId, UnitKey(*booking), Nights(*booking)
+	if booking.PromoCode == nil {
+		return store.deleteHoldingNights(ctx, bookingId, propertyId, unitKey, nights, nil)
+	}
+	err = store.deleteHoldingNights(ctx, bookingId, propertyId, unitKey, nights, store.release(*booking.PromoCode))
+	if errors.Is(err, errNotRedeemed) {
+		// the promotion is gone, there is no redemption to release
+		return store.deleteHoldingNights(ctx, bookingId, propertyId, unitKey, nights, nil)
+	}
+	return err
+}
+
+// deleteHoldingNights deletes the item keyed by the holder and releases the
+// locks of the nights of the property held by it, as a whole for an empty
+// unit key, and the redemption of a promo code if any.
+func (store *bookingsStore) deleteHoldingNights(ctx context.Context, holder string, propertyId int,
+	unitKey string, nights []time.Time, release *types.TransactWriteItem) error {
+
+	items := []types.TransactWriteItem{{
+		Delete: &types.Delete{
+			TableName: &store.table.tableName,
+			Key: map[string]types.AttributeValue{
+				"bookingId": &types.AttributeValueMemberS{Value: holder},
+			},
+			ConditionExpression: aws.String("attribute_exists(bookingId)"),
+		},
+	}}
+	for _, night := range nights {
+		items = append(items, store.releaseNight(holder, propertyId, unitKey, night))
+	}
+	if release != nil {
+		items = append(items, *release)
+	}
+
+	err := store.table.transactWriteItems(ctx, items)
+	failed := failedTransactItems(err)
+	switch {
+	case slices.Contains(failed, 0):
+		// the item has been removed in the meantime
+		return &Error{Op: opTransact, Table: store.table.tableName, Kind: ErrNotFound, Err: err}
+	case release != nil && slices.Equal(failed, []int{len(items) - 1}):
+		return &Error{Op: opTransact, Table: store.promotions.tableName, Kind: errNotRedeemed, Err: err}
+	case len(failed) > 0:
+		return &Error{Op: opTransact, Table: store.table.tableName, Kind: ErrConditionalCheckFailed, Err: err}
+	}
+	return err
+}
+
+// holdNight takes the lock of the night of the property for the holder. A
+// holder of the property as a whole, for an empty unit key, puts the lock
+// naming it, on the condition that neither another holder nor any unit
+// has it. A holder of a unit adds the unit to the units of the lock, on the
+// condition that no holder of the property as a whole has it and the unit
+// is not in it already.
+func (store *bookingsStore) holdNight(holder string, propertyId int, unitKey string,
+	night time.Time) types.TransactWriteItem {
+
+	key := nightLockKey(propertyId, night)
+	if unitKey == "" {
+		return types.TransactWriteItem{
+			Put: &types.Put{
+				TableName: &store.table.tableName,
+				Item: map[string]types.AttributeValue{
+					"bookingId": &types.AttributeValueMemberS{Value: key},
+					"heldBy":    &types.AttributeValueMemberS{Value: holder},
+				},
+				// a lock left without units is taken over
+				ConditionExpression: aws.String("attribute_not_exists(heldBy) AND attribute_not_exists(units)"),
+			},
+		}
+	}
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName: &store.table.tableName,
+			Key: map[string]types.AttributeValue{
+				"bookingId": &types.AttributeValueMemberS{Value: key},
+			},
+			UpdateExpression:    aws.String("ADD units :units"),
+			ConditionExpression: aws.String("attribute_not_exists(heldBy) AND NOT contains(units, :unit)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":units": &types.AttributeValueMemberSS{Value: []string{unitKey}},
+				":unit":  &types.AttributeValueMemberS{Value: unitKey},
+			},
+		},
+	}
+}
+
+// releaseNight releases the lock of the night of the property taken by
+// holdNight. The lock is released only if it is held by the holder, or has
+// the unit, or is missing, as for the bookings stored before the locks were
+// introduced. The lock of a unit is left without it, DynamoDB removing the
+// units once they are empty.
+func (store *bookingsStore) releaseNight(holder string, propertyId int, unitKey string,
+	night time.Time) types.TransactWriteItem {
+
 	key := map[string]types.AttributeValue{
-		"bookingId": &types.AttributeValueMemberS{Value: bookingId},
+		"bookingId": &types.AttributeValueMemberS{Value: nightLockKey(propertyId, night)},
 	}
-	return store.table.deleteItem(ctx, key)
+	if unitKey == "" {
+		return types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName:           &store.table.tableName,
+				Key:                 key,
+				ConditionExpression: aws.String("attribute_not_exists(bookingId) OR heldBy = :holder"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":holder": &types.AttributeValueMemberS{Value: holder},
+				},
+			},
+		}
+	}
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:           &store.table.tableName,
+			Key:                 key,
+			UpdateExpression:    aws.String("DELETE units :units"),
+			ConditionExpression: aws.String("attribute_not_exists(bookingId) OR contains(units, :unit)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":units": &types.AttributeValueMemberSS{Value: []string{unitKey}},
+				":unit":  &types.AttributeValueMemberS{Value: unitKey},
+			},
+		},
+	}
+}
+
+func nightLockKey(propertyId int, night time.Time) string {
+	return fmt.Sprintf("night#%d#%s", propertyId, night.Format(time.DateOnly))
 }
 
 type bookingWrapper struct {
@@ -2,21 +2,23 @@ package database
 
 import (
 	"context"
-	"errors"
-	"log"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/aws/smithy-go"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 )
 
 const (
-	ErrorFailedToFetchRecord     = "failed to fetch record"
-	ErrorFailedToUnmarshalRecord = "failed to unmarshal record"
-	ErrorFailedToRemoveRecord    = "failed to remove record"
-	ErrorNotFound                = "not found"
+	opGetItem    = "GetItem"
+	opQuery      = "Query"
+	opScan       = "Scan"
+	opPutItem    = "PutItem"
+	opDeleteItem = "DeleteItem"
+	opTransact   = "TransactWriteItems"
+	opUnmarshal  = "Unmarshal"
+	opMarshal    = "Marshal"
 )
 
 type table struct {
@@ -24,7 +26,13 @@ type table struct {
 	tableName string
 }
 
+// newTable creates a client of the table, tracing its calls with the
+// global tracer provider and recording their latency.
 func newTable(config aws.Config, tableName string) *table {
+	config = config.Copy()
+	otelaws.AppendMiddlewares(&config.APIOptions, otelaws.WithAttributeSetter(otelaws.DynamoDBAttributeSetter))
+	config.APIOptions = append(config.APIOptions, recordLatency(tableName))
+
 	return &table{
 		client:    dynamodb.NewFromConfig(config),
 		tableName: tableName,
@@ -35,26 +43,21 @@ func (t *table) getItem(ctx context.Context, key map[string]types.AttributeValue
 	map[string]types.AttributeValue, error) {
 
 	input := dynamodb.GetItemInput{
-		Key:       key,
-		TableName: &t.tableName,
+		Key:            key,
+		TableName:      &t.tableName,
+		ConsistentRead: aws.Bool(true),
 	}
 
 	// call the GetItem method to fetch data from dynamoDB table.
 	result, err := t.client.GetItem(ctx, &input)
-
-	// Check if the result is nil or if there is any error during fetching the record.
 	if err != nil {
-		var apiErr smithy.APIError
-		if errors.As(err, &apiErr) {
-			switch apiErr.(type) {
-			case *types.ResourceNotFoundException:
-				return nil, nil
-			}
-		}
-		log.Println(err)
-		return nil, errors.New(ErrorFailedToFetchRecord)
+		return nil, newError(opGetItem, t.tableName, err)
 	}
 
+	// an empty result means there is no item with the given key.
+	if len(result.Item) == 0 {
+		return nil, &Error{Op: opGetItem, Table: t.tableName, Kind: ErrNotFound}
+	}
 	return result.Item, nil
 }
 
@@ -62,16 +65,12 @@ func getItem[T any](ctx context.Context, key map[string]types.AttributeValue, t
 	item, err := t.getItem(ctx, key)
 	if err != nil {
 		return nil, err
-	} else if item == nil {
-		return nil, nil
 	}
 
 	result := new(T)
 	err = attributevalue.UnmarshalMap(item, result)
-
 	if err != nil {
-		log.Println(err)
-		return nil, errors.New(ErrorFailedToUnmarshalRecord)
+		return nil, newError(opUnmarshal, t.tableName, err)
 	}
 	return result, nil
 }
@@ -91,11 +90,8 @@ func (t *table) query(ctx context.Context, indexName *string,
 
 	// call the Query method to fetch data from dynamoDB table.
 	result, err := t.client.Query(ctx, &input)
-
-	// Check if the result is nil or if there is any error during fetching the record.
 	if err != nil {
-		log.Println(err)
-		return nil, errors.New(ErrorFailedToFetchRecord)
+		return nil, newError(opQuery, t.tableName, err)
 	}
 
 	return result.Items, nil
@@ -110,7 +106,6 @@ func query[T any](ctx context.Context, indexName *string,
 	item, err := t.query(ctx, indexName, keyConditionExpression,
 		filterExpression, expressionAttributeValues)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	} else if item == nil {
 		return nil, nil
@@ -118,62 +113,102 @@ func query[T any](ctx context.Context, indexName *string,
 
 	var result []T
 	err = attributevalue.UnmarshalListOfMaps(item, &result)
-
 	if err != nil {
-		log.Println(err)
-		return nil, errors.New(ErrorFailedToUnmarshalRecord)
+		return nil, newError(opUnmarshal, t.tableName, err)
 	}
 	return result, nil
 }
 
-func (t *table) putItem(ctx context.Context, item map[string]types.AttributeValue) error {
+// scan reads all the items of the table, page by page.
+func scan[T any](ctx context.Context, t *table) ([]T, error) {
+	paginator := dynamodb.NewScanPaginator(t.client, &dynamodb.ScanInput{
+		TableName:      &t.tableName,
+		ConsistentRead: aws.Bool(true),
+	})
+
+	var result []T
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, newError(opScan, t.tableName, err)
+		}
+		var items []T
+		err = attributevalue.UnmarshalListOfMaps(page.Items, &items)
+		if err != nil {
+			return nil, newError(opUnmarshal, t.tableName, err)
+		}
+		result = append(result, items...)
+	}
+	return result, nil
+}
+
+// putItem puts the item, on the condition if given, which may refer to the
+// names and values of its expression.
+func (t *table) putItem(ctx context.Context, item map[string]types.AttributeValue,
+	conditionExpression *string, names map[string]string, values map[string]types.AttributeValue) error {
+
 	input := dynamodb.PutItemInput{
-		Item:      item,
-		TableName: &t.tableName,
+		Item:                      item,
+		TableName:                 &t.tableName,
+		ConditionExpression:       conditionExpression,
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
 	}
 
 	_, err := t.client.PutItem(ctx, &input)
 	if err != nil {
-		log.Println(err)
-		return err
+		return newError(opPutItem, t.tableName, err)
 	}
 	return nil
 }
 
-func putItem[T any](ctx context.Context, item T, t *table) error {
+func putItem[T any](ctx context.Context, item T, conditionExpression *string, t *table) error {
+	itemMap, err := marshalItem(item, t)
+	if err != nil {
+		return err
+	}
+	return t.putItem(ctx, itemMap, conditionExpression, nil, nil)
+}
+
+func marshalItem[T any](item T, t *table) (map[string]types.AttributeValue, error) {
 	itemMap, err := attributevalue.MarshalMapWithOptions(item,
 		func(opt *attributevalue.EncoderOptions) {
 			opt.TagKey = "json"
 		})
 	if err != nil {
-		log.Println(err)
-		return err
+		return nil, newError(opMarshal, t.tableName, err)
 	}
-	return t.putItem(ctx, itemMap)
+	return itemMap, nil
 }
 
-func (t *table) deleteItem(ctx context.Context, key map[string]types.AttributeValue) error {
+func (t *table) deleteItem(ctx context.Context, key map[string]types.AttributeValue,
+	conditionExpression *string) error {
 
 	input := dynamodb.DeleteItemInput{
-		Key:       key,
-		TableName: &t.tableName,
+		Key:                 key,
+		TableName:           &t.tableName,
+		ConditionExpression: conditionExpression,
 	}
 
 	// call the DeleteItem method to remove data from dynamoDB table.
 	_, err := t.client.DeleteItem(ctx, &input)
-
-	// Check if the result is nil or if there is any error during fetching the record.
 	if err != nil {
-		var apiErr smithy.APIError
-		if errors.As(err, &apiErr) {
-			switch apiErr.(type) {
-			case *types.ResourceNotFoundException:
-				return errors.New(ErrorNotFound)
-			}
-		}
-		log.Println(err)
-		return errors.New(ErrorFailedToRemoveRecord)
+		return newError(opDeleteItem, t.tableName, err)
+	}
+
+	return nil
+}
+
+// transactWriteItems writes all the items or none of them. The failed
+// conditions can be told apart with failedTransactItems.
+func (t *table) transactWriteItems(ctx context.Context, items []types.TransactWriteItem) error {
+	input := dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
 	}
 
+	_, err := t.client.TransactWriteItems(ctx, &input)
+	if err != nil {
+		return newError(opTransact, t.tableName, err)
+	}
 	return nil
 }
@@ -0,0 +1,810 @@
+// Package databasetest provides the contract every storage backend has to
+// fulfil, as a test suite run against each of them.
+package databasetest
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// TestBookingsStore runs the contract of database.BookingsStore. newStore
+// has to return an empty store for every call.
+func TestBookingsStore(t *testing.T, newStore func(t *testing.T) database.BookingsStore) {
+	ctx := context.Background()
+
+	t.Run("get added booking", func(t *testing.T) {
+		store := newStore(t)
+		booking := NewBooking(1, "2024-07-01", "2024-07-05")
+		mustAddBooking(t, store, booking)
+
+		got, err := store.GetBooking(ctx, booking.BookingId)
+		if err != nil {
+			t.Fatalf("GetBooking() error = %v", err)
+		}
+		assertSameBooking(t, *got, booking)
+	})
+
+	t.Run("get missing booking", func(t *testing.T) {
+		store := newStore(t)
+
+		_, err := store.GetBooking(ctx, uuid.NewString())
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("add booking with taken id", func(t *testing.T) {
+		store := newStore(t)
+		booking := NewBooking(1, "2024-07-01", "2024-07-05")
+		mustAddBooking(t, store, booking)
+
+		duplicate := NewBooking(2, "2024-08-01", "2024-08-05")
+		duplicate.BookingId = booking.BookingId
+		err := store.AddBooking(ctx, duplicate)
+		assertErrorIs(t, err, database.ErrConditionalCheckFailed)
+	})
+
+	t.Run("add overlapping booking", func(t *testing.T) {
+		store := newStore(t)
+		mustAddBooking(t, store, NewBooking(1, "2024-07-10", "2024-07-15"))
+
+		for _, dates := range [][2]string{
+			{"2024-07-08", "2024-07-11"},
+			{"2024-07-14", "2024-07-20"},
+			{"2024-07-11", "2024-07-12"},
+			{"2024-07-01", "2024-07-30"},
+		} {
+			err := store.AddBooking(ctx, NewBooking(1, dates[0], dates[1]))
+			assertErrorIs(t, err, database.ErrOverlap)
+		}
+	})
+
+	t.Run("add adjacent and other property bookings", func(t *testing.T) {
+		store := newStore(t)
+		mustAddBooking(t, store, NewBooking(1, "2024-07-10", "2024-07-15"))
+
+		mustAddBooking(t, store, NewBooking(1, "2024-07-05", "2024-07-10"))
+		mustAddBooking(t, store, NewBooking(1, "2024-07-15", "2024-07-20"))
+		mustAddBooking(t, store, NewBooking(2, "2024-07-10", "2024-07-15"))
+	})
+
+	t.Run("add bookings of units", func(t *testing.T) {
+		store := newStore(t)
+		mustAddBooking(t, store, NewUnitBooking(1, "double", 1, "2024-07-10", "2024-07-15"))
+
+		// the other units of the type and of the other types are free
+		mustAddBooking(t, store, NewUnitBooking(1, "double", 2, "2024-07-10", "2024-07-15"))
+		mustAddBooking(t, store, NewUnitBooking(1, "single", 1, "2024-07-10", "2024-07-15"))
+
+		err := store.AddBooking(ctx, NewUnitBooking(1, "double", 1, "2024-07-14", "2024-07-16"))
+		assertErrorIs(t, err, database.ErrOverlap)
+
+		got, err := store.GetBookingsForProperty(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetBookingsForProperty() error = %v", err)
+		}
+		for _, booking := range got {
+			if booking.RoomTypeId == nil || booking.Unit == nil {
+				t.Errorf("GetBookingsForProperty() returned booking %+v without its unit", booking)
+			}
+		}
+	})
+
+	t.Run("add bookings of units and of the property as a whole", func(t *testing.T) {
+		store := newStore(t)
+		unit := NewUnitBooking(1, "double", 1, "2024-07-10", "2024-07-15")
+		mustAddBooking(t, store, unit)
+		mustAddBooking(t, store, NewBooking(1, "2024-07-20", "2024-07-25"))
+
+		err := store.AddBooking(ctx, NewBooking(1, "2024-07-14", "2024-07-16"))
+		assertErrorIs(t, err, database.ErrOverlap)
+		err = store.AddBooking(ctx, NewUnitBooking(1, "single", 1, "2024-07-24", "2024-07-26"))
+		assertErrorIs(t, err, database.ErrOverlap)
+
+		// the nights of the removed unit are free for the property as a whole
+		if err := store.RemoveBooking(ctx, unit.BookingId); err != nil {
+			t.Fatalf("RemoveBooking() error = %v", err)
+		}
+		mustAddBooking(t, store, NewBooking(1, "2024-07-14", "2024-07-16"))
+	})
+
+	t.Run("get bookings for property", func(t *testing.T) {
+		store := newStore(t)
+		first := NewBooking(1, "2024-07-01", "2024-07-05")
+		second := NewBooking(1, "2024-07-10", "2024-07-12")
+		mustAddBooking(t, store, first)
+		mustAddBooking(t, store, second)
+		mustAddBooking(t, store, NewBooking(2, "2024-07-01", "2024-07-05"))
+
+		bookings, err := store.GetBookingsForProperty(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetBookingsForProperty() error = %v", err)
+		}
+		if len(bookings) != 2 {
+			t.Fatalf("GetBookingsForProperty() returned %d bookings, want 2", len(bookings))
+		}
+		for _, booking := range bookings {
+			switch booking.BookingId {
+			case first.BookingId:
+				assertSameBooking(t, booking, first)
+			case second.BookingId:
+				assertSameBooking(t, booking, second)
+			default:
+				t.Errorf("GetBookingsForProperty() returned unexpected booking %s", booking.BookingId)
+			}
+		}
+
+		bookings, err = store.GetBookingsForProperty(ctx, 3)
+		if err != nil {
+			t.Fatalf("GetBookingsForProperty() error = %v", err)
+		}
+		if len(bookings) != 0 {
+			t.Errorf("GetBookingsForProperty() returned %d bookings, want none", len(bookings))
+		}
+	})
+
+	t.Run("remove booking", func(t *testing.T) {
+		store := newStore(t)
+		booking := NewBooking(1, "2024-07-01", "2024-07-05")
+		mustAddBooking(t, store, booking)
+
+		if err := store.RemoveBooking(ctx, booking.BookingId); err != nil {
+			t.Fatalf("RemoveBooking() error = %v", err)
+		}
+		_, err := store.GetBooking(ctx, booking.BookingId)
+		assertErrorIs(t, err, database.ErrNotFound)
+
+		// the nights are free again
+		mustAddBooking(t, store, NewBooking(1, "2024-07-01", "2024-07-05"))
+	})
+
+	t.Run("remove missing booking", func(t *testing.T) {
+		store := newStore(t)
+
+		err := store.RemoveBooking(ctx, uuid.NewString())
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("add concurrent bookings", func(t *testing.T) {
+		store := newStore(t)
+
+		const attempts = 8
+		errs := make([]error, attempts)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = store.AddBooking(ctx, NewBooking(1, "2024-09-01", "2024-09-04"))
+			}(i)
+		}
+		wg.Wait()
+
+		added := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				added++
+			case !errors.Is(err, database.ErrOverlap) && !errors.Is(err, database.ErrConditionalCheckFailed):
+				t.Errorf("AddBooking() error = %v, want ErrOverlap", err)
+			}
+		}
+		if added != 1 {
+			t.Errorf("%d of the concurrent bookings were added, want 1", added)
+		}
+	})
+}
+
+// TestBlocksStore runs the contract of database.BlocksStore along with the
+// bookings store of the same backend, which the blocks share the nights
+// with. newStores has to return empty stores for every call.
+func TestBlocksStore(t *testing.T,
+	newStores func(t *testing.T) (database.BookingsStore, database.BlocksStore)) {
+
+	ctx := context.Background()
+
+	t.Run("get added blocks", func(t *testing.T) {
+		bookings, store := newStores(t)
+		block := NewBlock(1, "2024-07-01", "2024-07-05")
+		mustAddBlock(t, store, block)
+		mustAddBlock(t, store, NewBlock(2, "2024-07-01", "2024-07-05"))
+		mustAddBooking(t, bookings, NewBooking(1, "2024-07-10", "2024-07-12"))
+
+		blocks, err := store.GetBlocksForProperty(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetBlocksForProperty() error = %v", err)
+		}
+		if len(blocks) != 1 {
+			t.Fatalf("GetBlocksForProperty() returned %d blocks, want 1", len(blocks))
+		}
+		assertSameBlock(t, blocks[0], block)
+
+		// the blocks are no bookings
+		got, err := bookings.GetBookingsForProperty(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetBookingsForProperty() error = %v", err)
+		}
+		if len(got) != 1 {
+			t.Errorf("GetBookingsForProperty() returned %d bookings, want 1", len(got))
+		}
+		_, err = bookings.GetBooking(ctx, block.BlockId.String())
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("add block with taken id", func(t *testing.T) {
+		_, store := newStores(t)
+		block := NewBlock(1, "2024-07-01", "2024-07-05")
+		mustAddBlock(t, store, block)
+
+		duplicate := NewBlock(2, "2024-08-01", "2024-08-05")
+		duplicate.BlockId = block.BlockId
+		err := store.AddBlock(ctx, duplicate)
+		assertErrorIs(t, err, database.ErrConditionalCheckFailed)
+	})
+
+	t.Run("block booked and blocked nights", func(t *testing.T) {
+		bookings, store := newStores(t)
+		mustAddBooking(t, bookings, NewBooking(1, "2024-07-10", "2024-07-15"))
+		mustAddBlock(t, store, NewBlock(1, "2024-07-20", "2024-07-25"))
+
+		for _, dates := range [][2]string{
+			{"2024-07-08", "2024-07-11"},
+			{"2024-07-14", "2024-07-21"},
+			{"2024-07-22", "2024-07-23"},
+		} {
+			err := store.AddBlock(ctx, NewBlock(1, dates[0], dates[1]))
+			assertErrorIs(t, err, database.ErrOverlap)
+		}
+
+		// the nights in between and the ones of another property are free
+		mustAddBlock(t, store, NewBlock(1, "2024-07-15", "2024-07-20"))
+		mustAddBlock(t, store, NewBlock(2, "2024-07-10", "2024-07-15"))
+	})
+
+	t.Run("book blocked nights", func(t *testing.T) {
+		bookings, store := newStores(t)
+		mustAddBlock(t, store, NewBlock(1, "2024-07-10", "2024-07-15"))
+
+		err := bookings.AddBooking(ctx, NewBooking(1, "2024-07-14", "2024-07-16"))
+		assertErrorIs(t, err, database.ErrOverlap)
+		mustAddBooking(t, bookings, NewBooking(1, "2024-07-15", "2024-07-16"))
+	})
+
+	t.Run("block and book units", func(t *testing.T) {
+		bookings, store := newStores(t)
+		mustAddBooking(t, bookings, NewUnitBooking(1, "double", 1, "2024-07-10", "2024-07-15"))
+		mustAddBlock(t, store, NewBlock(1, "2024-07-20", "2024-07-25"))
+
+		err := store.AddBlock(ctx, NewBlock(1, "2024-07-14", "2024-07-16"))
+		assertErrorIs(t, err, database.ErrOverlap)
+		err = bookings.AddBooking(ctx, NewUnitBooking(1, "double", 2, "2024-07-24", "2024-07-26"))
+		assertErrorIs(t, err, database.ErrOverlap)
+		mustAddBooking(t, bookings, NewUnitBooking(1, "double", 2, "2024-07-15", "2024-07-20"))
+	})
+
+	t.Run("remove block", func(t *testing.T) {
+		bookings, store := newStores(t)
+		block := NewBlock(1, "2024-07-01", "2024-07-05")
+		mustAddBlock(t, store, block)
+
+		if err := store.RemoveBlock(ctx, block.BlockId.String()); err != nil {
+			t.Fatalf("RemoveBlock() error = %v", err)
+		}
+		blocks, err := store.GetBlocksForProperty(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetBlocksForProperty() error = %v", err)
+		}
+		if len(blocks) != 0 {
+			t.Errorf("GetBlocksForProperty() returned %d blocks, want none", len(blocks))
+		}
+
+		// the nights are free again
+		mustAddBooking(t, bookings, NewBooking(1, "2024-07-01", "2024-07-05"))
+	})
+
+	t.Run("remove missing block", func(t *testing.T) {
+		bookings, store := newStores(t)
+		booking := NewBooking(1, "2024-07-01", "2024-07-05")
+		mustAddBooking(t, bookings, booking)
+
+		err := store.RemoveBlock(ctx, uuid.NewString())
+		assertErrorIs(t, err, database.ErrNotFound)
+		// nor does a booking count as a block
+		err = store.RemoveBlock(ctx, booking.BookingId)
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+}
+
+// TestPromotionsStore runs the contract of database.PromotionsStore along
+// with the bookings store of the same backend, which redeems the promotions.
+// newStores has to return empty stores for every call.
+func TestPromotionsStore(t *testing.T,
+	newStores func(t *testing.T) (database.BookingsStore, database.PromotionsStore)) {
+
+	ctx := context.Background()
+
+	t.Run("get added promotions", func(t *testing.T) {
+		_, store := newStores(t)
+		promotion := NewPromotion("SUMMER", nil)
+		promotion.PropertyIds = &[]int{1, 2}
+		mustAddPromotion(t, store, promotion)
+		mustAddPromotion(t, store, NewPromotion("AUTUMN", nil))
+
+		got, err := store.GetPromotion(ctx, "SUMMER")
+		if err != nil {
+			t.Fatalf("GetPromotion() error = %v", err)
+		}
+		assertSamePromotion(t, *got, promotion)
+
+		promotions, err := store.GetPromotions(ctx)
+		if err != nil {
+			t.Fatalf("GetPromotions() error = %v", err)
+		}
+		if len(promotions) != 2 || promotions[0].Code != "AUTUMN" || promotions[1].Code != "SUMMER" {
+			t.Errorf("GetPromotions() = %+v, want AUTUMN and SUMMER", promotions)
+		}
+	})
+
+	t.Run("add promotion with taken code", func(t *testing.T) {
+		_, store := newStores(t)
+		mustAddPromotion(t, store, NewPromotion("SUMMER", nil))
+
+		err := store.AddPromotion(ctx, NewPromotion("SUMMER", nil))
+		assertErrorIs(t, err, database.ErrConditionalCheckFailed)
+	})
+
+	t.Run("get missing promotion", func(t *testing.T) {
+		_, store := newStores(t)
+
+		_, err := store.GetPromotion(ctx, "SUMMER")
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("remove promotion", func(t *testing.T) {
+		_, store := newStores(t)
+		mustAddPromotion(t, store, NewPromotion("SUMMER", nil))
+
+		if err := store.RemovePromotion(ctx, "SUMMER"); err != nil {
+			t.Fatalf("RemovePromotion() error = %v", err)
+		}
+		_, err := store.GetPromotion(ctx, "SUMMER")
+		assertErrorIs(t, err, database.ErrNotFound)
+		err = store.RemovePromotion(ctx, "SUMMER")
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("redeem promotion", func(t *testing.T) {
+		bookings, store := newStores(t)
+		limit := 2
+		mustAddPromotion(t, store, NewPromotion("SUMMER", &limit))
+
+		mustAddBooking(t, bookings, NewPromoBooking(1, "SUMMER", "2024-07-01", "2024-07-05"))
+		mustAddBooking(t, bookings, NewPromoBooking(1, "SUMMER", "2024-07-05", "2024-07-10"))
+		assertRedemptions(t, store, "SUMMER", 2)
+
+		// the promotion is used up, the booking is not stored
+		booking := NewPromoBooking(1, "SUMMER", "2024-07-10", "2024-07-15")
+		err := bookings.AddBooking(ctx, booking)
+		assertErrorIs(t, err, database.ErrLimitReached)
+		_, err = bookings.GetBooking(ctx, booking.BookingId)
+		assertErrorIs(t, err, database.ErrNotFound)
+		assertRedemptions(t, store, "SUMMER", 2)
+	})
+
+	t.Run("redeem promotion on overlapping booking", func(t *testing.T) {
+		bookings, store := newStores(t)
+		mustAddPromotion(t, store, NewPromotion("SUMMER", nil))
+		mustAddBooking(t, bookings, NewBooking(1, "2024-07-01", "2024-07-05"))
+
+		err := bookings.AddBooking(ctx, NewPromoBooking(1, "SUMMER", "2024-07-04", "2024-07-06"))
+		assertErrorIs(t, err, database.ErrOverlap)
+		assertRedemptions(t, store, "SUMMER", 0)
+	})
+
+	t.Run("release redemption of removed booking", func(t *testing.T) {
+		bookings, store := newStores(t)
+		limit := 1
+		mustAddPromotion(t, store, NewPromotion("SUMMER", &limit))
+		booking := NewPromoBooking(1, "SUMMER", "2024-07-01", "2024-07-05")
+		mustAddBooking(t, bookings, booking)
+
+		if err := bookings.RemoveBooking(ctx, booking.BookingId); err != nil {
+			t.Fatalf("RemoveBooking() error = %v", err)
+		}
+		assertRedemptions(t, store, "SUMMER", 0)
+		// the released redemption may be redeemed again
+		mustAddBooking(t, bookings, NewPromoBooking(1, "SUMMER", "2024-07-01", "2024-07-05"))
+		assertRedemptions(t, store, "SUMMER", 1)
+	})
+
+	t.Run("remove booking of removed promotion", func(t *testing.T) {
+		bookings, store := newStores(t)
+		mustAddPromotion(t, store, NewPromotion("SUMMER", nil))
+		booking := NewPromoBooking(1, "SUMMER", "2024-07-01", "2024-07-05")
+		mustAddBooking(t, bookings, booking)
+		if err := store.RemovePromotion(ctx, "SUMMER"); err != nil {
+			t.Fatalf("RemovePromotion() error = %v", err)
+		}
+
+		if err := bookings.RemoveBooking(ctx, booking.BookingId); err != nil {
+			t.Fatalf("RemoveBooking() error = %v", err)
+		}
+		_, err := bookings.GetBooking(ctx, booking.BookingId)
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("redeem missing promotion", func(t *testing.T) {
+		bookings, _ := newStores(t)
+
+		err := bookings.AddBooking(ctx, NewPromoBooking(1, "SUMMER", "2024-07-01", "2024-07-05"))
+		assertErrorIs(t, err, database.ErrLimitReached)
+	})
+}
+
+// TestPropertiesStore runs the contract of database.PropertiesStore.
+// newStore has to return an empty store for every call.
+func TestPropertiesStore(t *testing.T, newStore func(t *testing.T) database.PropertiesStore) {
+	ctx := context.Background()
+
+	t.Run("get put property", func(t *testing.T) {
+		store := newStore(t)
+		property := NewProperty(1, "Krakow", "Poland", 2, 4)
+		mustPutProperty(t, store, property)
+
+		got, err := store.GetProperty(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetProperty() error = %v", err)
+		}
+		if got.PropertyId != 1 || got.City != "Krakow" || got.Size != property.Size ||
+			got.Layout == nil || *got.Layout != *property.Layout {
+			t.Errorf("GetProperty() = %+v, want %+v", *got, property)
+		}
+	})
+
+	t.Run("get missing property", func(t *testing.T) {
+		store := newStore(t)
+
+		_, err := store.GetProperty(ctx, 1)
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		store := newStore(t)
+		mustPutProperty(t, store, NewProperty(1, "Krakow", "Poland", 2, 4))
+		mustPutProperty(t, store, NewProperty(2, "Krakow", "Poland", 3, 6))
+		mustPutProperty(t, store, NewProperty(3, "Gdansk", "Poland", 2, 4))
+		mustPutProperty(t, store, NewProperty(4, "Berlin", "Germany", 2, 4))
+
+		city, country, bedrooms, guests := "Krakow", "Poland", 2, 4
+		for _, tc := range []struct {
+			name    string
+			options domain.SearchOptions
+			want    []int
+		}{
+			{"city", domain.SearchOptions{City: &city}, []int{1, 2}},
+			{"country", domain.SearchOptions{Country: &country}, []int{1, 2, 3}},
+			{"country and bedrooms", domain.SearchOptions{Country: &country, Bedrooms: &bedrooms}, []int{1, 3}},
+			{"city and guests", domain.SearchOptions{City: &city, Guests: &guests}, []int{1}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				properties, err := store.Search(ctx, tc.options)
+				if err != nil {
+					t.Fatalf("Search() error = %v", err)
+				}
+				got := map[int]bool{}
+				for _, property := range properties {
+					got[property.PropertyId] = true
+				}
+				if len(got) != len(tc.want) {
+					t.Fatalf("Search() returned %v, want %v", got, tc.want)
+				}
+				for _, id := range tc.want {
+					if !got[id] {
+						t.Errorf("Search() returned %v, want %v", got, tc.want)
+					}
+				}
+			})
+		}
+	})
+}
+
+// TestDraftsStore runs the contract of database.DraftsStore. newStore has to
+// return an empty store for every call.
+func TestDraftsStore(t *testing.T, newStore func(t *testing.T) database.DraftsStore) {
+	ctx := context.Background()
+
+	t.Run("get put draft", func(t *testing.T) {
+		store := newStore(t)
+		draft := NewDraft(time.Hour)
+		mustPutDraft(t, store, draft)
+
+		got, err := store.GetDraft(ctx, draft.SessionId)
+		if err != nil {
+			t.Fatalf("GetDraft() error = %v", err)
+		}
+		assertSameDraft(t, *got, draft)
+	})
+
+	t.Run("put draft replaces it", func(t *testing.T) {
+		store := newStore(t)
+		draft := NewDraft(time.Hour)
+		mustPutDraft(t, store, draft)
+
+		name := "Jane Doe"
+		draft.Fields.CustomerName = &name
+		draft.ExpiresAt = draft.ExpiresAt.Add(time.Hour)
+		draft.Version = 1
+		mustPutDraft(t, store, draft)
+
+		got, err := store.GetDraft(ctx, draft.SessionId)
+		if err != nil {
+			t.Fatalf("GetDraft() error = %v", err)
+		}
+		assertSameDraft(t, *got, draft)
+		if got.Version != 2 {
+			t.Errorf("GetDraft() version = %d, want 2", got.Version)
+		}
+	})
+
+	t.Run("put stale draft", func(t *testing.T) {
+		store := newStore(t)
+		draft := NewDraft(time.Hour)
+		mustPutDraft(t, store, draft)
+		draft.Version = 1
+		mustPutDraft(t, store, draft)
+
+		// put on the version read before the last put
+		err := store.PutDraft(ctx, draft)
+		assertErrorIs(t, err, database.ErrConditionalCheckFailed)
+		draft.Version = 0
+		err = store.PutDraft(ctx, draft)
+		assertErrorIs(t, err, database.ErrConditionalCheckFailed)
+	})
+
+	t.Run("put draft over expired one", func(t *testing.T) {
+		store := newStore(t)
+		expired := NewDraft(-time.Minute)
+		mustPutDraft(t, store, expired)
+
+		draft := NewDraft(time.Hour)
+		draft.SessionId = expired.SessionId
+		mustPutDraft(t, store, draft)
+
+		got, err := store.GetDraft(ctx, draft.SessionId)
+		if err != nil {
+			t.Fatalf("GetDraft() error = %v", err)
+		}
+		assertSameDraft(t, *got, draft)
+	})
+
+	t.Run("get missing draft", func(t *testing.T) {
+		store := newStore(t)
+
+		_, err := store.GetDraft(ctx, uuid.NewString())
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("get expired draft", func(t *testing.T) {
+		store := newStore(t)
+		draft := NewDraft(-time.Minute)
+		mustPutDraft(t, store, draft)
+
+		_, err := store.GetDraft(ctx, draft.SessionId)
+		assertErrorIs(t, err, database.ErrNotFound)
+		err = store.RemoveDraft(ctx, draft.SessionId)
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("remove draft", func(t *testing.T) {
+		store := newStore(t)
+		draft := NewDraft(time.Hour)
+		mustPutDraft(t, store, draft)
+
+		if err := store.RemoveDraft(ctx, draft.SessionId); err != nil {
+			t.Fatalf("RemoveDraft() error = %v", err)
+		}
+		_, err := store.GetDraft(ctx, draft.SessionId)
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("remove missing draft", func(t *testing.T) {
+		store := newStore(t)
+
+		err := store.RemoveDraft(ctx, uuid.NewString())
+		assertErrorIs(t, err, database.ErrNotFound)
+	})
+}
+
+// NewBooking creates a booking with a random ID for the property and dates.
+func NewBooking(propertyId int, startDate, endDate string) domain.Booking {
+	email := "john.doe@example.com"
+	return domain.Booking{
+		BookingId: uuid.NewString(),
+		BookingRequest: domain.BookingRequest{
+			PropertyId:     propertyId,
+			CustomerName:   "John Doe",
+			StartDate:      Date(startDate),
+			EndDate:        Date(endDate),
+			ContactDetails: domain.ContactDetails{Email: &email},
+		},
+	}
+}
+
+// NewUnitBooking creates a booking of the unit of the room type.
+func NewUnitBooking(propertyId int, roomTypeId string, unit int, startDate, endDate string) domain.Booking {
+	booking := NewBooking(propertyId, startDate, endDate)
+	booking.RoomTypeId = &roomTypeId
+	booking.Unit = &unit
+	return booking
+}
+
+// NewBlock creates a block with a random ID for the property and dates.
+func NewBlock(propertyId int, startDate, endDate string) domain.Block {
+	return domain.Block{
+		BlockId:    uuid.New(),
+		PropertyId: propertyId,
+		StartDate:  Date(startDate),
+		EndDate:    Date(endDate),
+		Reason:     "Maintenance",
+	}
+}
+
+// NewPromoBooking creates a booking redeeming the promo code.
+func NewPromoBooking(propertyId int, code, startDate, endDate string) domain.Booking {
+	booking := NewBooking(propertyId, startDate, endDate)
+	booking.PromoCode = &code
+	return booking
+}
+
+// NewPromotion creates a promotion of 10 percent off, redeemable at most
+// maxRedemptions times, with no limit if nil.
+func NewPromotion(code string, maxRedemptions *int) domain.Promotion {
+	percent, description := float32(10), "10% off"
+	validUntil := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return domain.Promotion{
+		Code:            code,
+		Description:     &description,
+		DiscountPercent: &percent,
+		ValidUntil:      &validUntil,
+		MaxRedemptions:  maxRedemptions,
+	}
+}
+
+// NewProperty creates a property with the attributes the search matches.
+func NewProperty(propertyId int, city, country string, bedrooms, guests int) domain.Property {
+	layout := "open space"
+	return domain.Property{
+		PropertyId: propertyId,
+		Address:    "1 Main St",
+		City:       city,
+		Country:    country,
+		Location:   "city centre",
+		Size:       50 + bedrooms*20,
+		Bedrooms:   bedrooms,
+		Guests:     guests,
+		Layout:     &layout,
+	}
+}
+
+// NewDraft creates a draft of a random session with some of the fields set,
+// expiring after ttl.
+func NewDraft(ttl time.Duration) domain.Draft {
+	propertyId, name, startDate := 1, "John Doe", Date("2024-07-01")
+	return domain.Draft{
+		SessionId: uuid.NewString(),
+		Fields: domain.BookingDraftFields{
+			PropertyId:   &propertyId,
+			CustomerName: &name,
+			StartDate:    &startDate,
+		},
+		ExpiresAt: time.Now().Add(ttl).Truncate(time.Second),
+	}
+}
+
+// Date parses a YYYY-MM-DD date, panicking if it is not valid.
+func Date(value string) openapi_types.Date {
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return openapi_types.Date{Time: date}
+}
+
+func mustAddBooking(t *testing.T, store database.BookingsStore, booking domain.Booking) {
+	t.Helper()
+	if err := store.AddBooking(context.Background(), booking); err != nil {
+		t.Fatalf("AddBooking(%s - %s) error = %v", booking.StartDate, booking.EndDate, err)
+	}
+}
+
+func mustAddBlock(t *testing.T, store database.BlocksStore, block domain.Block) {
+	t.Helper()
+	if err := store.AddBlock(context.Background(), block); err != nil {
+		t.Fatalf("AddBlock(%s - %s) error = %v", block.StartDate, block.EndDate, err)
+	}
+}
+
+func mustAddPromotion(t *testing.T, store database.PromotionsStore, promotion domain.Promotion) {
+	t.Helper()
+	if err := store.AddPromotion(context.Background(), promotion); err != nil {
+		t.Fatalf("AddPromotion(%s) error = %v", promotion.Code, err)
+	}
+}
+
+func mustPutProperty(t *testing.T, store database.PropertiesStore, property domain.Property) {
+	t.Helper()
+	if err := store.PutProperty(context.Background(), property); err != nil {
+		t.Fatalf("PutProperty() error = %v", err)
+	}
+}
+
+func mustPutDraft(t *testing.T, store database.DraftsStore, draft domain.Draft) {
+	t.Helper()
+	if err := store.PutDraft(context.Background(), draft); err != nil {
+		t.Fatalf("PutDraft() error = %v", err)
+	}
+}
+
+func assertSameDraft(t *testing.T, got, want domain.Draft) {
+	t.Helper()
+	if got.SessionId != want.SessionId || !got.ExpiresAt.Equal(want.ExpiresAt) ||
+		*got.Fields.PropertyId != *want.Fields.PropertyId || *got.Fields.CustomerName != *want.Fields.CustomerName ||
+		!got.Fields.StartDate.Equal(want.Fields.StartDate.Time) || got.Fields.EndDate != nil {
+		t.Errorf("got draft %+v, want %+v", got, want)
+	}
+}
+
+func assertSameBooking(t *testing.T, got, want domain.Booking) {
+	t.Helper()
+	if got.BookingId != want.BookingId || got.PropertyId != want.PropertyId ||
+		got.CustomerName != want.CustomerName ||
+		!got.StartDate.Equal(want.StartDate.Time) || !got.EndDate.Equal(want.EndDate.Time) {
+		t.Errorf("got booking %+v, want %+v", got, want)
+	}
+	if got.ContactDetails.Email == nil || *got.ContactDetails.Email != *want.ContactDetails.Email {
+		t.Errorf("got contact details %+v, want %+v", got.ContactDetails, want.ContactDetails)
+	}
+}
+
+func assertSameBlock(t *testing.T, got, want domain.Block) {
+	t.Helper()
+	if got.BlockId != want.BlockId || got.PropertyId != want.PropertyId || got.Reason != want.Reason ||
+		!got.StartDate.Equal(want.StartDate.Time) || !got.EndDate.Equal(want.EndDate.Time) {
+		t.Errorf("got block %+v, want %+v", got, want)
+	}
+}
+
+func assertSamePromotion(t *testing.T, got, want domain.Promotion) {
+	t.Helper()
+	if got.Code != want.Code || *got.Description != *want.Description ||
+		*got.DiscountPercent != *want.DiscountPercent || got.DiscountAmount != nil ||
+		!got.ValidUntil.Equal(*want.ValidUntil) || got.ValidFrom != nil ||
+		!slices.Equal(*got.PropertyIds, *want.PropertyIds) || got.Redemptions != 0 {
+		t.Errorf("got promotion %+v, want %+v", got, want)
+	}
+}
+
+func assertRedemptions(t *testing.T, store database.PromotionsStore, code string, want int) {
+	t.Helper()
+	promotion, err := store.GetPromotion(context.Background(), code)
+	if err != nil {
+		t.Fatalf("GetPromotion() error = %v", err)
+	}
+	if promotion.Redemptions != want {
+		t.Errorf("got %d redemptions, want %d", promotion.Redemptions, want)
+	}
+}
+
+func assertErrorIs(t *testing.T, err, target error) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("error = %v, want %v", err, target)
+	}
+}
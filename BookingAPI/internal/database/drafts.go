@@ -0,0 +1,91 @@
+package database
+
+import (
+	"booking/configuration"
+	"booking/internal/domain"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type draftsStore struct {
+	table *table
+}
+
+func NewDraftsStore(config configuration.Config) *draftsStore {
+	return &draftsStore{
+		table: newTable(config.AwsConfig, config.DraftsTableName),
+	}
+}
+
+// PutDraft stores the next version of the draft with its expiry as the TTL
+// of the item, so that DynamoDB deletes the abandoned drafts. The item is put
+// only if it still has the version of the draft or has expired; the items
+// stored before the drafts had versions count as version 0.
+func (store *draftsStore) PutDraft(ctx context.Context, draft domain.Draft) error {
+	version := draft.Version
+	draft.Version++
+	item, err := marshalItem(draftWrapper{Draft: draft, TTL: draft.ExpiresAt.Unix()}, store.table)
+	if err != nil {
+		return err
+	}
+
+	// DynamoDB rejects the values the condition does not use
+	condition := "attribute_not_exists(version) OR #ttl <= :now"
+	values := map[string]types.AttributeValue{
+		":now": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+	}
+	if version > 0 {
+		condition = "version = :version OR #ttl <= :now"
+		values[":version"] = &types.AttributeValueMemberN{Value: strconv.Itoa(version)}
+	}
+	return store.table.putItem(ctx, item, aws.String(condition), map[string]string{"#ttl": "ttl"}, values)
+}
+
+// GetDraft reads the draft of the session. DynamoDB deletes the expired
+// items only eventually, so they are told apart by their expiry.
+func (store *draftsStore) GetDraft(ctx context.Context, sessionId string) (*domain.Draft, error) {
+	wrapped, err := getItem[draftWrapper](
+		ctx,
+		map[string]types.AttributeValue{
+			"sessionId": &types.AttributeValueMemberS{Value: sessionId},
+		},
+		store.table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !wrapped.ExpiresAt.After(time.Now()) {
+		return nil, &Error{Op: opGetItem, Table: store.table.tableName, Kind: ErrNotFound}
+	}
+	return &wrapped.Draft, nil
+}
+
+func (store *draftsStore) RemoveDraft(ctx context.Context, sessionId string) error {
+	if _, err := store.GetDraft(ctx, sessionId); err != nil {
+		return err
+	}
+
+	err := store.table.deleteItem(ctx,
+		map[string]types.AttributeValue{
+			"sessionId": &types.AttributeValueMemberS{Value: sessionId},
+		},
+		aws.String("attribute_exists(sessionId)"),
+	)
+	if errors.Is(err, ErrConditionalCheckFailed) {
+		// the draft has been removed in the meantime
+		return &Error{Op: opDeleteItem, Table: store.table.tableName, Kind: ErrNotFound, Err: err}
+	}
+	return err
+}
+
+type draftWrapper struct {
+	domain.Draft
+	// TTL is the expiry in seconds since the epoch, the attribute the time
+	// to live of the table is enabled on.
+	TTL int64 `json:"ttl"`
+}
@@ -0,0 +1,149 @@
+package database_test
+
+import (
+	"booking/configuration"
+	"booking/internal/database"
+	"booking/internal/database/databasetest"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+)
+
+// The DynamoDB stores are tested against a local instance, e.g. the one
+// started with local/compose.yaml, by setting DYNAMODB_TEST_ENDPOINT to
+// its URL (http://localhost:8000).
+const envTestEndpoint = "DYNAMODB_TEST_ENDPOINT"
+
+func TestBookingsStore(t *testing.T) {
+	awsConfig := testAwsConfig(t)
+	databasetest.TestBookingsStore(t, func(t *testing.T) database.BookingsStore {
+		tableName := createTable(t, awsConfig, keyS("bookingId"), map[string]types.AttributeDefinition{
+			"PropertyIdIndex": keyN("propertyId"),
+		})
+		return database.NewBookingsStore(configuration.Config{
+			AwsConfig:         awsConfig,
+			BookingsTableName: tableName,
+		})
+	})
+}
+
+func TestBlocksStore(t *testing.T) {
+	awsConfig := testAwsConfig(t)
+	databasetest.TestBlocksStore(t, func(t *testing.T) (database.BookingsStore, database.BlocksStore) {
+		tableName := createTable(t, awsConfig, keyS("bookingId"), map[string]types.AttributeDefinition{
+			"PropertyIdIndex": keyN("propertyId"),
+		})
+		store := database.NewBookingsStore(configuration.Config{
+			AwsConfig:         awsConfig,
+			BookingsTableName: tableName,
+		})
+		return store, store
+	})
+}
+
+func TestPromotionsStore(t *testing.T) {
+	awsConfig := testAwsConfig(t)
+	databasetest.TestPromotionsStore(t, func(t *testing.T) (database.BookingsStore, database.PromotionsStore) {
+		tableName := createTable(t, awsConfig, keyS("bookingId"), map[string]types.AttributeDefinition{
+			"PropertyIdIndex": keyN("propertyId"),
+		})
+		promotionsTableName := createTable(t, awsConfig, keyS("code"), nil)
+		store := database.NewBookingsStore(configuration.Config{
+			AwsConfig:           awsConfig,
+			BookingsTableName:   tableName,
+			PromotionsTableName: promotionsTableName,
+		})
+		return store, store
+	})
+}
+
+func TestPropertiesStore(t *testing.T) {
+	awsConfig := testAwsConfig(t)
+	databasetest.TestPropertiesStore(t, func(t *testing.T) database.PropertiesStore {
+		tableName := createTable(t, awsConfig, keyN("propertyId"), map[string]types.AttributeDefinition{
+			"CityIndex":     keyS("city"),
+			"CountryIndex":  keyS("country"),
+			"BedroomsIndex": keyN("bedrooms"),
+			"GuestsIndex":   keyN("guests"),
+		})
+		return database.NewPropertiesStore(configuration.Config{
+			AwsConfig:           awsConfig,
+			PropertiesTableName: tableName,
+		})
+	})
+}
+
+func TestDraftsStore(t *testing.T) {
+	awsConfig := testAwsConfig(t)
+	databasetest.TestDraftsStore(t, func(t *testing.T) database.DraftsStore {
+		tableName := createTable(t, awsConfig, keyS("sessionId"), nil)
+		return database.NewDraftsStore(configuration.Config{
+			AwsConfig:       awsConfig,
+			DraftsTableName: tableName,
+		})
+	})
+}
+
+func testAwsConfig(t *testing.T) aws.Config {
+	endpoint := os.Getenv(envTestEndpoint)
+	if endpoint == "" {
+		t.Skipf("%s is not set", envTestEndpoint)
+	}
+	return aws.Config{
+		Region:       "eu-central-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("local", "local", ""),
+		BaseEndpoint: &endpoint,
+	}
+}
+
+// createTable creates a table with a random name and the hash key and the
+// global secondary indexes defined by template.yaml, the table is deleted
+// when the test completes.
+func createTable(t *testing.T, awsConfig aws.Config, key types.AttributeDefinition,
+	indexKeys map[string]types.AttributeDefinition) string {
+
+	t.Helper()
+	ctx := context.Background()
+	client := dynamodb.NewFromConfig(awsConfig)
+	tableName := "test-" + uuid.NewString()
+
+	attributes := []types.AttributeDefinition{key}
+	var indexes []types.GlobalSecondaryIndex
+	for indexName, indexKey := range indexKeys {
+		attributes = append(attributes, indexKey)
+		indexes = append(indexes, types.GlobalSecondaryIndex{
+			IndexName:  aws.String(indexName),
+			KeySchema:  []types.KeySchemaElement{{AttributeName: indexKey.AttributeName, KeyType: types.KeyTypeHash}},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		})
+	}
+
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName:              &tableName,
+		BillingMode:            types.BillingModePayPerRequest,
+		AttributeDefinitions:   attributes,
+		KeySchema:              []types.KeySchemaElement{{AttributeName: key.AttributeName, KeyType: types.KeyTypeHash}},
+		GlobalSecondaryIndexes: indexes,
+	})
+	if err != nil {
+		t.Fatalf("CreateTable() error = %v", err)
+	}
+	t.Cleanup(func() {
+		client.DeleteTable(ctx, &dynamodb.DeleteTableInput{TableName: &tableName})
+	})
+	return tableName
+}
+
+func keyS(name string) types.AttributeDefinition {
+	return types.AttributeDefinition{AttributeName: aws.String(name), AttributeType: types.ScalarAttributeTypeS}
+}
+
+func keyN(name string) types.AttributeDefinition {
+	return types.AttributeDefinition{AttributeName: aws.String(name), AttributeType: types.ScalarAttributeTypeN}
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+// Sentinel errors classifying the failures of the stores. Match them with
+// errors.Is, the *Error carrying them keeps the underlying cause.
+var (
+	ErrNotFound               = errors.New("not found")
+	ErrConditionalCheckFailed = errors.New("conditional check failed")
+	ErrThrottled              = errors.New("throttled")
+	ErrValidation             = errors.New("validation failed")
+	ErrOverlap                = errors.New("overlaps an existing booking")
+	ErrLimitReached           = errors.New("usage limit reached")
+)
+
+// Error describes a failed operation on a table. Kind is one of the sentinel
+// errors or nil when the failure is not classified, Err is the cause, e.g.
+// the AWS API error, and may be nil when the failure has no other cause.
+type Error struct {
+	Op    string
+	Table string
+	Kind  error
+	Err   error
+}
+
+func (err *Error) Error() string {
+	message := fmt.Sprintf("%s on %s", err.Op, err.Table)
+	if err.Kind != nil {
+		message += ": " + err.Kind.Error()
+	}
+	if err.Err != nil {
+		message += ": " + err.Err.Error()
+	}
+	return message
+}
+
+func (err *Error) Unwrap() []error {
+	var wrapped []error
+	if err.Kind != nil {
+		wrapped = append(wrapped, err.Kind)
+	}
+	if err.Err != nil {
+		wrapped = append(wrapped, err.Err)
+	}
+	return wrapped
+}
+
+// newError wraps the error returned by the AWS SDK, classifying it.
+func newError(op, table string, err error) *Error {
+	return &Error{Op: op, Table: table, Kind: classify(err), Err: err}
+}
+
+// classify returns the sentinel error of the AWS API error, nil if there is
+// none. A missing table, ResourceNotFoundException, is left unclassified: it
+// is a deployment failure, not a missing item.
+func classify(err error) error {
+	var conditionalCheckErr *types.ConditionalCheckFailedException
+	var transactionErr *types.TransactionCanceledException
+	var throughputErr *types.ProvisionedThroughputExceededException
+	var requestLimitErr *types.RequestLimitExceeded
+	var apiErr smithy.APIError
+
+	switch {
+	case errors.As(err, &conditionalCheckErr):
+		return ErrConditionalCheckFailed
+	case errors.As(err, &transactionErr):
+		for _, reason := range transactionErr.CancellationReasons {
+			switch aws.ToString(reason.Code) {
+			case "ConditionalCheckFailed":
+				return ErrConditionalCheckFailed
+			case "ThrottlingError", "ProvisionedThroughputExceeded", "RequestLimitExceeded":
+				return ErrThrottled
+			case "ValidationError":
+				return ErrValidation
+			}
+		}
+	case errors.As(err, &throughputErr), errors.As(err, &requestLimitErr):
+		return ErrThrottled
+	case errors.As(err, &apiErr):
+		switch apiErr.ErrorCode() {
+		case "ThrottlingException":
+			return ErrThrottled
+		case "ValidationException":
+			return ErrValidation
+		}
+	}
+	return nil
+}
+
+// failedTransactItems returns the indexes of the items whose condition
+// made the transaction fail.
+func failedTransactItems(err error) []int {
+	var transactionErr *types.TransactionCanceledException
+	if !errors.As(err, &transactionErr) {
+		return nil
+	}
+
+	var failed []int
+	for i, reason := range transactionErr.CancellationReasons {
+		if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+// operationError wraps the API error the way the AWS SDK returns it.
+func operationError(err error) error {
+	return &smithy.OperationError{ServiceID: "DynamoDB", OperationName: "PutItem", Err: err}
+}
+
+func cancellationReasons(codes ...string) *types.TransactionCanceledException {
+	reasons := make([]types.CancellationReason, len(codes))
+	for i, code := range codes {
+		reasons[i] = types.CancellationReason{Code: aws.String(code)}
+	}
+	return &types.TransactionCanceledException{CancellationReasons: reasons}
+}
+
+func TestNewError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"conditional check", &types.ConditionalCheckFailedException{}, ErrConditionalCheckFailed},
+		{"transaction condition", cancellationReasons("None", "ConditionalCheckFailed"), ErrConditionalCheckFailed},
+		{"transaction throttled", cancellationReasons("None", "ThrottlingError"), ErrThrottled},
+		{"transaction validation", cancellationReasons("ValidationError"), ErrValidation},
+		{"transaction conflict", cancellationReasons("TransactionConflict"), nil},
+		{"provisioned throughput", &types.ProvisionedThroughputExceededException{}, ErrThrottled},
+		{"request limit", &types.RequestLimitExceeded{}, ErrThrottled},
+		{"throttling", &smithy.GenericAPIError{Code: "ThrottlingException"}, ErrThrottled},
+		{"validation", &smithy.GenericAPIError{Code: "ValidationException"}, ErrValidation},
+		{"missing table", &types.ResourceNotFoundException{}, nil},
+		{"other", errors.New("connection reset"), nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := fmt.Errorf("put booking: %w", newError("PutItem", "bookings", operationError(tc.err)))
+
+			var storeErr *Error
+			if !errors.As(err, &storeErr) {
+				t.Fatalf("errors.As(%v) = false, want the *Error", err)
+			}
+			if storeErr.Kind != tc.kind {
+				t.Errorf("Kind = %v, want %v", storeErr.Kind, tc.kind)
+			}
+			if tc.kind != nil && !errors.Is(err, tc.kind) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tc.kind)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("errors.Is(%v, cause) = false, want the cause kept", err)
+			}
+			for _, kind := range []error{ErrNotFound, ErrConditionalCheckFailed, ErrThrottled, ErrValidation} {
+				if kind != tc.kind && errors.Is(err, kind) {
+					t.Errorf("errors.Is(%v, %v) = true", err, kind)
+				}
+			}
+		})
+	}
+}
+
+func TestNewErrorAPIError(t *testing.T) {
+	err := fmt.Errorf("get property: %w", newError("GetItem", "properties",
+		operationError(&types.ResourceNotFoundException{Message: aws.String("table not found")})))
+
+	var missingTable *types.ResourceNotFoundException
+	if !errors.As(err, &missingTable) || aws.ToString(missingTable.Message) != "table not found" {
+		t.Errorf("errors.As(%v) = false, want the ResourceNotFoundException", err)
+	}
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "ResourceNotFoundException" {
+		t.Errorf("errors.As(%v) = false, want the API error", err)
+	}
+}
+
+func TestFailedTransactItems(t *testing.T) {
+	err := operationError(cancellationReasons("None", "ConditionalCheckFailed", "None", "ConditionalCheckFailed"))
+	if failed := failedTransactItems(err); !slices.Equal(failed, []int{1, 3}) {
+		t.Errorf("failedTransactItems() = %v, want [1 3]", failed)
+	}
+	if failed := failedTransactItems(operationError(&types.ConditionalCheckFailedException{})); failed != nil {
+		t.Errorf("failedTransactItems() = %v, want nil", failed)
+	}
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+)
+
+func (store *bookingsStore) AddBlock(ctx context.Context, block domain.Block) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	blockId := block.BlockId.String()
+	if _, ok := store.blocks[blockId]; ok {
+		return &database.Error{Op: "AddBlock", Table: bookingsTable, Kind: database.ErrConditionalCheckFailed}
+	}
+	if store.taken(block.PropertyId, "", block.StartDate, block.EndDate) {
+		return &database.Error{Op: "AddBlock", Table: bookingsTable, Kind: database.ErrOverlap}
+	}
+
+	store.blocks[blockId] = block
+	return nil
+}
+
+func (store *bookingsStore) GetBlocksForProperty(ctx context.Context, propertyId int) ([]domain.Block, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	var blocks []domain.Block
+	for _, block := range store.blocks {
+		if block.PropertyId == propertyId {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks, nil
+}
+
+func (store *bookingsStore) RemoveBlock(ctx context.Context, blockId string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, ok := store.blocks[blockId]; !ok {
+		return &database.Error{Op: "RemoveBlock", Table: bookingsTable, Kind: database.ErrNotFound}
+	}
+	delete(store.blocks, blockId)
+	return nil
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"sync"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const bookingsTable = "memory:bookings"
+
+// bookingsStore keeps the blocks along with the bookings, as they share the
+// nights of the properties, and the promotions, as the bookings redeem them.
+type bookingsStore struct {
+	mutex      sync.RWMutex
+	bookings   map[string]domain.Booking
+	blocks     map[string]domain.Block
+	promotions map[string]domain.Promotion
+}
+
+func NewBookingsStore() *bookingsStore {
+	return &bookingsStore{
+		bookings:   map[string]domain.Booking{},
+		blocks:     map[string]domain.Block{},
+		promotions: map[string]domain.Promotion{},
+	}
+}
+
+func (store *bookingsStore) AddBooking(ctx context.Context, booking domain.Booking) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, ok := store.bookings[booking.BookingId]; ok {
+		return &database.Error{Op: "AddBooking", Table: bookingsTable, Kind: database.ErrConditionalCheckFailed}
+	}
+	if store.taken(booking.PropertyId, database.UnitKey(booking), booking.StartDate, booking.EndDate) {
+		return &database.Error{Op: "AddBooking", Table: bookingsTable, Kind: database.ErrOverlap}
+	}
+	if booking.PromoCode != nil {
+		promotion, ok := store.promotions[*booking.PromoCode]
+		if !ok || (promotion.MaxRedemptions != nil && promotion.Redemptions >= *promotion.MaxRedemptions) {
+			return &database.Error{Op: "AddBooking", Table: bookingsTable, Kind: database.ErrLimitReached}
+		}
+		promotion.Redemptions++
+		store.promotions[*booking.PromoCode] = promotion
+	}
+
+	store.bookings[booking.BookingId] = booking
+	return nil
+}
+
+func (store *bookingsStore) GetBookingsForProperty(ctx context.Context, propertyId int) ([]domain.Booking, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	var bookings []domain.Booking
+	for _, booking := range store.bookings {
+		if booking.PropertyId == propertyId {
+			bookings = append(bookings, booking)
+		}
+	}
+	return bookings, nil
+}
+
+func (store *bookingsStore) GetBooking(ctx context.Context, bookingId string) (*domain.Booking, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	booking, ok := store.bookings[bookingId]
+	if !ok {
+		return nil, &database.Error{Op: "GetBooking", Table: bookingsTable, Kind: database.ErrNotFound}
+	}
+	return &booking, nil
+}
+
+func (store *bookingsStore) RemoveBooking(ctx context.Context, bookingId string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	booking, ok := store.bookings[bookingId]
+	if !ok {
+		return &database.Error{Op: "RemoveBooking", Table: bookingsTable, Kind: database.ErrNotFound}
+	}
+	if booking.PromoCode != nil {
+		if promotion, ok := store.promotions[*booking.PromoCode]; ok && promotion.Redemptions > 0 {
+			promotion.Redemptions--
+			store.promotions[*booking.PromoCode] = promotion
+		}
+	}
+	delete(store.bookings, bookingId)
+	return nil
+}
+
+// taken tells whether any of the nights of the unit, the property as a
+// whole for an empty unit key, is booked for an overlapping unit or blocked,
+// the blocks holding the property as a whole.
+func (store *bookingsStore) taken(propertyId int, unitKey string, startDate, endDate openapi_types.Date) bool {
+	for _, existing := range store.bookings {
+		if existing.PropertyId == propertyId && database.UnitsOverlap(database.UnitKey(existing), unitKey) &&
+			overlap(existing.StartDate, existing.EndDate, startDate, endDate) {
+
+			return true
+		}
+	}
+	for _, existing := range store.blocks {
+		if existing.PropertyId == propertyId &&
+			overlap(existing.StartDate, existing.EndDate, startDate, endDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlap tells whether the date ranges share at least one night.
+func overlap(startDate1, endDate1, startDate2, endDate2 openapi_types.Date) bool {
+	return startDate1.Before(endDate2.Time) && endDate1.After(startDate2.Time)
+}
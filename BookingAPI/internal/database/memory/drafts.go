@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"sync"
+	"time"
+)
+
+const draftsTable = "memory:drafts"
+
+type draftsStore struct {
+	mutex  sync.Mutex
+	drafts map[string]domain.Draft
+}
+
+func NewDraftsStore() *draftsStore {
+	return &draftsStore{
+		drafts: map[string]domain.Draft{},
+	}
+}
+
+// PutDraft stores the next version of the draft, dropping the expired ones
+// on the way.
+func (store *draftsStore) PutDraft(ctx context.Context, draft domain.Draft) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := time.Now()
+	for sessionId, existing := range store.drafts {
+		if !existing.ExpiresAt.After(now) {
+			delete(store.drafts, sessionId)
+		}
+	}
+	if store.drafts[draft.SessionId].Version != draft.Version {
+		return &database.Error{Op: "PutDraft", Table: draftsTable, Kind: database.ErrConditionalCheckFailed}
+	}
+	draft.Version++
+	store.drafts[draft.SessionId] = draft
+	return nil
+}
+
+func (store *draftsStore) GetDraft(ctx context.Context, sessionId string) (*domain.Draft, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	draft, ok := store.drafts[sessionId]
+	if !ok || !draft.ExpiresAt.After(time.Now()) {
+		return nil, &database.Error{Op: "GetDraft", Table: draftsTable, Kind: database.ErrNotFound}
+	}
+	return &draft, nil
+}
+
+func (store *draftsStore) RemoveDraft(ctx context.Context, sessionId string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	draft, ok := store.drafts[sessionId]
+	if !ok || !draft.ExpiresAt.After(time.Now()) {
+		return &database.Error{Op: "RemoveDraft", Table: draftsTable, Kind: database.ErrNotFound}
+	}
+	delete(store.drafts, sessionId)
+	return nil
+}
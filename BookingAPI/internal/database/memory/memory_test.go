@@ -0,0 +1,40 @@
+package memory_test
+
+import (
+	"booking/internal/database"
+	"booking/internal/database/databasetest"
+	"booking/internal/database/memory"
+	"testing"
+)
+
+func TestBookingsStore(t *testing.T) {
+	databasetest.TestBookingsStore(t, func(t *testing.T) database.BookingsStore {
+		return memory.NewBookingsStore()
+	})
+}
+
+func TestBlocksStore(t *testing.T) {
+	databasetest.TestBlocksStore(t, func(t *testing.T) (database.BookingsStore, database.BlocksStore) {
+		store := memory.NewBookingsStore()
+		return store, store
+	})
+}
+
+func TestPromotionsStore(t *testing.T) {
+	databasetest.TestPromotionsStore(t, func(t *testing.T) (database.BookingsStore, database.PromotionsStore) {
+		store := memory.NewBookingsStore()
+		return store, store
+	})
+}
+
+func TestPropertiesStore(t *testing.T) {
+	databasetest.TestPropertiesStore(t, func(t *testing.T) database.PropertiesStore {
+		return memory.NewPropertiesStore()
+	})
+}
+
+func TestDraftsStore(t *testing.T) {
+	databasetest.TestDraftsStore(t, func(t *testing.T) database.DraftsStore {
+		return memory.NewDraftsStore()
+	})
+}
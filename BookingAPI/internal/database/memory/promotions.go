@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"sort"
+)
+
+func (store *bookingsStore) AddPromotion(ctx context.Context, promotion domain.Promotion) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, ok := store.promotions[promotion.Code]; ok {
+		return &database.Error{Op: "AddPromotion", Table: bookingsTable, Kind: database.ErrConditionalCheckFailed}
+	}
+	store.promotions[promotion.Code] = promotion
+	return nil
+}
+
+func (store *bookingsStore) GetPromotion(ctx context.Context, code string) (*domain.Promotion, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	promotion, ok := store.promotions[code]
+	if !ok {
+		return nil, &database.Error{Op: "GetPromotion", Table: bookingsTable, Kind: database.ErrNotFound}
+	}
+	return &promotion, nil
+}
+
+func (store *bookingsStore) GetPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	var promotions []domain.Promotion
+	for _, promotion := range store.promotions {
+		promotions = append(promotions, promotion)
+	}
+	sort.Slice(promotions, func(i, j int) bool {
+		return promotions[i].Code < promotions[j].Code
+	})
+	return promotions, nil
+}
+
+func (store *bookingsStore) RemovePromotion(ctx context.Context, code string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, ok := store.promotions[code]; !ok {
+		return &database.Error{Op: "RemovePromotion", Table: bookingsTable, Kind: database.ErrNotFound}
+	}
+	delete(store.promotions, code)
+	return nil
+}
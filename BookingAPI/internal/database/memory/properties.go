@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"sort"
+	"sync"
+)
+
+const propertiesTable = "memory:properties"
+
+type propertiesStore struct {
+	mutex      sync.RWMutex
+	properties map[int]domain.Property
+}
+
+// NewPropertiesStore creates a store holding the given properties.
+func NewPropertiesStore(properties ...domain.Property) *propertiesStore {
+	store := &propertiesStore{
+		properties: map[int]domain.Property{},
+	}
+	for _, property := range properties {
+		store.properties[property.PropertyId] = property
+	}
+	return store
+}
+
+func (store *propertiesStore) PutProperty(ctx context.Context, property domain.Property) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.properties[property.PropertyId] = property
+	return nil
+}
+
+func (store *propertiesStore) GetProperty(ctx context.Context, propertyId int) (*domain.Property, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	property, ok := store.properties[propertyId]
+	if !ok {
+		return nil, &database.Error{Op: "GetProperty", Table: propertiesTable, Kind: database.ErrNotFound}
+	}
+	return &property, nil
+}
+
+func (store *propertiesStore) Search(ctx context.Context, options domain.SearchOptions) ([]domain.Property, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	var properties []domain.Property
+	for _, property := range store.properties {
+		if matches(property, options) {
+			properties = append(properties, property)
+		}
+	}
+
+	// keep the results stable, unlike the map iteration
+	sort.Slice(properties, func(i, j int) bool {
+		return properties[i].PropertyId < properties[j].PropertyId
+	})
+	return properties, nil
+}
+
+func matches(property domain.Property, options domain.SearchOptions) bool {
+	return (options.City == nil || property.City == *options.City) &&
+		(options.Country == nil || property.Country == *options.Country) &&
+		(options.Bedrooms == nil || property.Bedrooms == *options.Bedrooms) &&
+		(options.Guests == nil || property.Guests == *options.Guests)
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"booking/internal/metrics"
+	"context"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// recordLatency records the duration of every call to the table, retries
+// included, as the DynamoDBLatency metric of the operation.
+func recordLatency(tableName string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("RecordLatency",
+			func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+				middleware.InitializeOutput, middleware.Metadata, error) {
+
+				start := time.Now()
+				out, metadata, err := next.HandleInitialize(ctx, in)
+				metrics.Record(ctx, metrics.DynamoDBLatency,
+					float64(time.Since(start).Microseconds())/1000, metrics.UnitMilliseconds,
+					metrics.Dimension{Name: "Operation", Value: awsmiddleware.GetOperationName(ctx)},
+					metrics.Dimension{Name: "Table", Value: tableName})
+				return out, metadata, err
+			}), middleware.After)
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"booking/internal/domain"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// The promotions are kept in a table of their own, keyed by their code, and
+// served by the bookings store, which redeems them in the transaction adding
+// the bookings.
+
+func (store *bookingsStore) AddPromotion(ctx context.Context, promotion domain.Promotion) error {
+	return putItem(ctx, promotion, aws.String("attribute_not_exists(code)"), store.promotions)
+}
+
+func (store *bookingsStore) GetPromotion(ctx context.Context, code string) (*domain.Promotion, error) {
+	return getItem[domain.Promotion](
+		ctx,
+		map[string]types.AttributeValue{
+			"code": &types.AttributeValueMemberS{Value: code},
+		},
+		store.promotions,
+	)
+}
+
+// GetPromotions returns all the promotions, sorted by their code.
+func (store *bookingsStore) GetPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	promotions, err := scan[domain.Promotion](ctx, store.promotions)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(promotions, func(a, b domain.Promotion) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return promotions, nil
+}
+
+func (store *bookingsStore) RemovePromotion(ctx context.Context, code string) error {
+	err := store.promotions.deleteItem(ctx,
+		map[string]types.AttributeValue{
+			"code": &types.AttributeValueMemberS{Value: code},
+		},
+		aws.String("attribute_exists(code)"),
+	)
+	if errors.Is(err, ErrConditionalCheckFailed) {
+		return &Error{Op: opDeleteItem, Table: store.promotions.tableName, Kind: ErrNotFound, Err: err}
+	}
+	return err
+}
+
+// redemption counts a redemption of the promo code, on the condition that
+// the promotion exists and is not used up.
+func (store *bookingsStore) redemption(code string) *types.TransactWriteItem {
+	return &types.TransactWriteItem{
+		Update: &types.Update{
+			TableName: &store.promotions.tableName,
+			Key: map[string]types.AttributeValue{
+				"code": &types.AttributeValueMemberS{Value: code},
+			},
+			UpdateExpression: aws.String("SET redemptions = redemptions + :one"),
+			ConditionExpression: aws.String(
+				"attribute_exists(code) AND (attribute_not_exists(maxRedemptions) OR redemptions < maxRedemptions)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":one": &types.AttributeValueMemberN{Value: "1"},
+			},
+		},
+	}
+}
+
+// release takes back a redemption of the promo code, on the condition that
+// the promotion exists and has been redeemed.
+func (store *bookingsStore) release(code string) *types.TransactWriteItem {
+	return &types.TransactWriteItem{
+		Update: &types.Update{
+			TableName: &store.promotions.tableName,
+			Key: map[string]types.AttributeValue{
+				"code": &types.AttributeValueMemberS{Value: code},
+			},
+			UpdateExpression:    aws.String("SET redemptions = redemptions - :one"),
+			ConditionExpression: aws.String("attribute_exists(code) AND redemptions > :zero"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":one":  &types.AttributeValueMemberN{Value: "1"},
+				":zero": &types.AttributeValueMemberN{Value: "0"},
+			},
+		},
+	}
+}
@@ -20,6 +20,10 @@ func NewPropertiesStore(config configuration.Config) *propertiesStore {
 	}
 }
 
+func (store *propertiesStore) PutProperty(ctx context.Context, property domain.Property) error {
+	return putItem(ctx, property, nil, store.table)
+}
+
 func (store *propertiesStore) GetProperty(ctx context.Context, propertyId int) (*domain.Property, error) {
 	return getItem[domain.Property](
 		ctx,
@@ -0,0 +1,67 @@
+package sqldb
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"encoding/json"
+)
+
+// The blocks are rows of the bookings table of the kind 'block', keyed by
+// the block ID, so that the database keeps them apart from the bookings the
+// same way it keeps the bookings apart.
+
+func (store *bookingsStore) AddBlock(ctx context.Context, block domain.Block) error {
+	data, err := json.Marshal(block)
+	if err != nil {
+		return &database.Error{Op: opMarshal, Table: bookingsTable, Err: err}
+	}
+
+	_, err = store.db.db.ExecContext(ctx, store.db.rebind(
+		`INSERT INTO bookings (booking_id, kind, property_id, start_date, end_date, data)
+		VALUES (?, 'block', ?, ?, ?, ?)`),
+		block.BlockId.String(), block.PropertyId, block.StartDate.String(), block.EndDate.String(), string(data))
+	if err != nil {
+		return store.db.newError("AddBlock", bookingsTable, err)
+	}
+	return nil
+}
+
+func (store *bookingsStore) GetBlocksForProperty(ctx context.Context, propertyId int) ([]domain.Block, error) {
+	rows, err := store.db.db.QueryContext(ctx, store.db.rebind(
+		"SELECT data FROM bookings WHERE property_id = ? AND kind = 'block' ORDER BY start_date"), propertyId)
+	if err != nil {
+		return nil, store.db.newError("GetBlocksForProperty", bookingsTable, err)
+	}
+	defer rows.Close()
+
+	var blocks []domain.Block
+	for rows.Next() {
+		block, err := scanData[domain.Block](store.db, rows, "GetBlocksForProperty", bookingsTable)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, *block)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, store.db.newError("GetBlocksForProperty", bookingsTable, err)
+	}
+	return blocks, nil
+}
+
+func (store *bookingsStore) RemoveBlock(ctx context.Context, blockId string) error {
+	result, err := store.db.db.ExecContext(ctx, store.db.rebind(
+		"DELETE FROM bookings WHERE booking_id = ? AND kind = 'block'"), blockId)
+	if err != nil {
+		return store.db.newError("RemoveBlock", bookingsTable, err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return store.db.newError("RemoveBlock", bookingsTable, err)
+	}
+	if removed == 0 {
+		return &database.Error{Op: "RemoveBlock", Table: bookingsTable, Kind: database.ErrNotFound}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package sqldb
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"encoding/json"
+)
+
+type bookingsStore struct {
+	db *DB
+}
+
+func NewBookingsStore(db *DB) *bookingsStore {
+	return &bookingsStore{db: db}
+}
+
+// AddBooking inserts the booking and redeems its promo code, if any, in one
+// transaction. The overlapping bookings of the same unit, or of the property
+// as a whole, and the blocks are rejected by the database, with the exclusion
+// constraint and the trigger on PostgreSQL and the triggers on SQLite, so no
+// check runs outside of the insert.
+func (store *bookingsStore) AddBooking(ctx context.Context, booking domain.Booking) error {
+	data, err := json.Marshal(booking)
+	if err != nil {
+		return &database.Error{Op: opMarshal, Table: bookingsTable, Err: err}
+	}
+
+	tx, err := store.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return store.db.newError("AddBooking", bookingsTable, err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, store.db.rebind(
+		`INSERT INTO bookings (booking_id, property_id, unit_key, start_date, end_date, data)
+		VALUES (?, ?, ?, ?, ?, ?)`),
+		booking.BookingId, booking.PropertyId, database.UnitKey(booking),
+		booking.StartDate.String(), booking.EndDate.String(), string(data))
+	if err != nil {
+		return store.db.newError("AddBooking", bookingsTable, err)
+	}
+	if booking.PromoCode != nil {
+		if err := store.redeem(ctx, tx, *booking.PromoCode); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return store.db.newError("AddBooking", bookingsTable, err)
+	}
+	return nil
+}
+
+func (store *bookingsStore) GetBookingsForProperty(ctx context.Context, propertyId int) ([]domain.Booking, error) {
+	rows, err := store.db.db.QueryContext(ctx, store.db.rebind(
+		"SELECT data FROM bookings WHERE property_id = ? AND kind = 'booking' ORDER BY start_date"), propertyId)
+	if err != nil {
+		return nil, store.db.newError("GetBookingsForProperty", bookingsTable, err)
+	}
+	defer rows.Close()
+
+	var bookings []domain.Booking
+	for rows.Next() {
+		booking, err := scanData[domain.Booking](store.db, rows, "GetBookingsForProperty", bookingsTable)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, *booking)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, store.db.newError("GetBookingsForProperty", bookingsTable, err)
+	}
+	return bookings, nil
+}
+
+func (store *bookingsStore) GetBooking(ctx context.Context, bookingId string) (*domain.Booking, error) {
+	row := store.db.db.QueryRowContext(ctx, store.db.rebind(
+		"SELECT data FROM bookings WHERE booking_id = ? AND kind = 'booking'"), bookingId)
+
+	return scanData[domain.Booking](store.db, row, "GetBooking", bookingsTable)
+}
+
+// RemoveBooking removes the booking and releases the redemption of its promo
+// code, if any, in one transaction.
+func (store *bookingsStore) RemoveBooking(ctx context.Context, bookingId string) error {
+	tx, err := store.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return store.db.newError("RemoveBooking", bookingsTable, err)
+	}
+	defer tx.Rollback()
+
+	row := tx.QueryRowContext(ctx, store.db.rebind(
+		"SELECT data FROM bookings WHERE booking_id = ? AND kind = 'booking'"), bookingId)
+	booking, err := scanData[domain.Booking](store.db, row, "RemoveBooking", bookingsTable)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, store.db.rebind(
+		"DELETE FROM bookings WHERE booking_id = ? AND kind = 'booking'"), bookingId)
+	if err != nil {
+		return store.db.newError("RemoveBooking", bookingsTable, err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return store.db.newError("RemoveBooking", bookingsTable, err)
+	}
+	if removed == 0 {
+		return &database.Error{Op: "RemoveBooking", Table: bookingsTable, Kind: database.ErrNotFound}
+	}
+	if booking.PromoCode != nil {
+		if err := store.release(ctx, tx, *booking.PromoCode); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return store.db.newError("RemoveBooking", bookingsTable, err)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanData scans the data column holding the item as JSON. A missing row
+// fails with database.ErrNotFound.
+func scanData[T any](db *DB, row scanner, op, table string) (*T, error) {
+	var data []byte
+	if err := row.Scan(&data); err != nil {
+		return nil, db.newError(op, table, err)
+	}
+
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, &database.Error{Op: opUnmarshal, Table: table, Err: err}
+	}
+	return &item, nil
+}
@@ -0,0 +1,90 @@
+package sqldb
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type draftsStore struct {
+	db *DB
+}
+
+func NewDraftsStore(db *DB) *draftsStore {
+	return &draftsStore{db: db}
+}
+
+// PutDraft stores the next version of the draft, removing the expired ones
+// first, as there is nothing else to clean them up. The expiry is kept in
+// seconds since the epoch, which compares the same way in both dialects.
+func (store *draftsStore) PutDraft(ctx context.Context, draft domain.Draft) error {
+	version := draft.Version
+	draft.Version++
+	data, err := json.Marshal(draft)
+	if err != nil {
+		return &database.Error{Op: opMarshal, Table: draftsTable, Err: err}
+	}
+
+	_, err = store.db.db.ExecContext(ctx, store.db.rebind(
+		"DELETE FROM booking_drafts WHERE expires_at <= ?"), time.Now().Unix())
+	if err != nil {
+		return store.db.newError("PutDraft", draftsTable, err)
+	}
+
+	// a stored draft of another version is left as it is, the draft being
+	// neither inserted nor updated
+	var result sql.Result
+	if version == 0 {
+		result, err = store.db.db.ExecContext(ctx, store.db.rebind(
+			`INSERT INTO booking_drafts (session_id, expires_at, data, version)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (session_id) DO NOTHING`),
+			draft.SessionId, draft.ExpiresAt.Unix(), string(data), draft.Version)
+	} else {
+		result, err = store.db.db.ExecContext(ctx, store.db.rebind(
+			`UPDATE booking_drafts SET expires_at = ?, data = ?, version = ?
+			WHERE session_id = ? AND version = ?`),
+			draft.ExpiresAt.Unix(), string(data), draft.Version, draft.SessionId, version)
+	}
+	if err != nil {
+		return store.db.newError("PutDraft", draftsTable, err)
+	}
+
+	put, err := result.RowsAffected()
+	if err != nil {
+		return store.db.newError("PutDraft", draftsTable, err)
+	}
+	if put == 0 {
+		return &database.Error{Op: "PutDraft", Table: draftsTable, Kind: database.ErrConditionalCheckFailed}
+	}
+	return nil
+}
+
+func (store *draftsStore) GetDraft(ctx context.Context, sessionId string) (*domain.Draft, error) {
+	row := store.db.db.QueryRowContext(ctx, store.db.rebind(
+		"SELECT data FROM booking_drafts WHERE session_id = ? AND expires_at > ?"),
+		sessionId, time.Now().Unix())
+
+	return scanData[domain.Draft](store.db, row, "GetDraft", draftsTable)
+}
+
+func (store *draftsStore) RemoveDraft(ctx context.Context, sessionId string) error {
+	result, err := store.db.db.ExecContext(ctx, store.db.rebind(
+		"DELETE FROM booking_drafts WHERE session_id = ? AND expires_at > ?"),
+		sessionId, time.Now().Unix())
+	if err != nil {
+		return store.db.newError("RemoveDraft", draftsTable, err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return store.db.newError("RemoveDraft", draftsTable, err)
+	}
+	if removed == 0 {
+		return &database.Error{Op: "RemoveDraft", Table: draftsTable, Kind: database.ErrNotFound}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package sqldb
+
+import (
+	"booking/internal/database"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const (
+	opMarshal   = "Marshal"
+	opUnmarshal = "Unmarshal"
+)
+
+// overlapConstraint names the constraint, or the triggers on SQLite,
+// rejecting the overlapping bookings.
+const overlapConstraint = "bookings_no_overlap"
+
+// newError wraps the error returned by the driver, classifying it.
+func (db *DB) newError(op, table string, err error) *database.Error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return &database.Error{Op: op, Table: table, Kind: database.ErrNotFound}
+	}
+	return &database.Error{Op: op, Table: table, Kind: db.dialect.classify(err), Err: err}
+}
+
+func classifySQLite(err error) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return nil
+	}
+
+	switch sqliteErr.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY, sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+		return database.ErrConditionalCheckFailed
+	case sqlite3.SQLITE_CONSTRAINT_CHECK, sqlite3.SQLITE_CONSTRAINT_NOTNULL:
+		return database.ErrValidation
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return database.ErrThrottled
+	}
+	if sqliteErr.Code()&0xff == sqlite3.SQLITE_CONSTRAINT &&
+		strings.Contains(sqliteErr.Error(), overlapConstraint) {
+		return database.ErrOverlap
+	}
+	return nil
+}
+
+// classifyPostgres classifies the error by its SQLSTATE code, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+func classifyPostgres(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	switch pgErr.Code {
+	case "23P01": // exclusion_violation
+		if pgErr.ConstraintName == overlapConstraint {
+			return database.ErrOverlap
+		}
+		return database.ErrConditionalCheckFailed
+	case "23505": // unique_violation
+		return database.ErrConditionalCheckFailed
+	case "23502", "23514", "22007", "22008": // not_null_violation, check_violation, invalid dates
+		return database.ErrValidation
+	case "40001", "40P01", "53300": // serialization_failure, deadlock_detected, too_many_connections
+		return database.ErrThrottled
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package sqldb
+
+import "context"
+
+// Truncate empties the tables, for the tests sharing a database.
+func (db *DB) Truncate(ctx context.Context) error {
+	_, err := db.db.ExecContext(ctx, "TRUNCATE bookings, properties, booking_drafts")
+	return err
+}
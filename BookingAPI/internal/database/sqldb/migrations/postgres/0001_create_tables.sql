@@ -0,0 +1,29 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE properties (
+    property_id INTEGER PRIMARY KEY,
+    city        TEXT    NOT NULL,
+    country     TEXT    NOT NULL,
+    bedrooms    INTEGER NOT NULL,
+    guests      INTEGER NOT NULL,
+    data        JSONB   NOT NULL
+);
+
+CREATE INDEX properties_city_idx ON properties (city);
+CREATE INDEX properties_country_idx ON properties (country);
+
+CREATE TABLE bookings (
+    booking_id  TEXT    PRIMARY KEY,
+    property_id INTEGER NOT NULL,
+    start_date  DATE    NOT NULL,
+    end_date    DATE    NOT NULL,
+    data        JSONB   NOT NULL,
+    CHECK (start_date < end_date),
+    -- the ranges are half-open, so a stay may start on the day another ends
+    CONSTRAINT bookings_no_overlap EXCLUDE USING gist (
+        property_id WITH =,
+        daterange(start_date, end_date) WITH &&
+    )
+);
+
+CREATE INDEX bookings_property_id_idx ON bookings (property_id);
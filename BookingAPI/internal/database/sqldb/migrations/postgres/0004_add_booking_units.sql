@@ -0,0 +1,10 @@
+-- unit_key identifies the unit of a room type a booking holds, empty for the
+-- property as a whole, and only the bookings of the same unit overlap.
+ALTER TABLE bookings ADD COLUMN unit_key TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE bookings DROP CONSTRAINT bookings_no_overlap;
+ALTER TABLE bookings ADD CONSTRAINT bookings_no_overlap EXCLUDE USING gist (
+    property_id WITH =,
+    unit_key WITH =,
+    daterange(start_date, end_date) WITH &&
+);
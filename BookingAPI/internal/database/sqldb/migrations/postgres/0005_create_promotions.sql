@@ -0,0 +1,8 @@
+-- redemptions is kept apart from the data, so that the bookings redeem the
+-- promo codes within the limit with a single update
+CREATE TABLE promotions (
+    code            TEXT    PRIMARY KEY,
+    max_redemptions INTEGER,
+    redemptions     INTEGER NOT NULL DEFAULT 0,
+    data            JSONB   NOT NULL
+);
@@ -0,0 +1,25 @@
+-- A booking of the property as a whole, with an empty unit_key, and a block
+-- overlap the bookings of all the units of the property. The exclusion
+-- constraint only compares the same units, so the trigger checks the others
+-- under a lock of the property, which keeps the concurrent writes of its
+-- bookings from missing each other.
+CREATE FUNCTION bookings_no_overlap() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(NEW.property_id);
+    IF EXISTS (
+        SELECT 1 FROM bookings
+        WHERE property_id = NEW.property_id
+          AND unit_key <> NEW.unit_key
+          AND (unit_key = '' OR NEW.unit_key = '')
+          AND booking_id <> NEW.booking_id
+          AND daterange(start_date, end_date) && daterange(NEW.start_date, NEW.end_date)
+    ) THEN
+        RAISE EXCEPTION 'booking overlaps another one of the property'
+            USING ERRCODE = 'exclusion_violation', CONSTRAINT = 'bookings_no_overlap';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER bookings_no_overlap BEFORE INSERT OR UPDATE OF property_id, unit_key, start_date, end_date
+ON bookings FOR EACH ROW EXECUTE FUNCTION bookings_no_overlap();
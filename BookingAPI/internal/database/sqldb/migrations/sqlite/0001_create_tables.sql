@@ -0,0 +1,48 @@
+CREATE TABLE properties (
+    property_id INTEGER PRIMARY KEY,
+    city        TEXT    NOT NULL,
+    country     TEXT    NOT NULL,
+    bedrooms    INTEGER NOT NULL,
+    guests      INTEGER NOT NULL,
+    data        TEXT    NOT NULL
+);
+
+CREATE INDEX properties_city_idx ON properties (city);
+CREATE INDEX properties_country_idx ON properties (country);
+
+CREATE TABLE bookings (
+    booking_id  TEXT    PRIMARY KEY,
+    property_id INTEGER NOT NULL,
+    start_date  TEXT    NOT NULL,
+    end_date    TEXT    NOT NULL,
+    data        TEXT    NOT NULL,
+    CHECK (start_date < end_date)
+);
+
+CREATE INDEX bookings_property_id_idx ON bookings (property_id);
+
+-- SQLite has no exclusion constraints, the triggers reject the bookings
+-- sharing a night with another booking of the property. The dates are
+-- stored as YYYY-MM-DD, so they compare as text.
+CREATE TRIGGER bookings_no_overlap_insert BEFORE INSERT ON bookings
+WHEN EXISTS (
+    SELECT 1 FROM bookings
+    WHERE property_id = NEW.property_id
+      AND start_date < NEW.end_date
+      AND end_date > NEW.start_date
+)
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap');
+END;
+
+CREATE TRIGGER bookings_no_overlap_update BEFORE UPDATE OF property_id, start_date, end_date ON bookings
+WHEN EXISTS (
+    SELECT 1 FROM bookings
+    WHERE property_id = NEW.property_id
+      AND booking_id <> NEW.booking_id
+      AND start_date < NEW.end_date
+      AND end_date > NEW.start_date
+)
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap');
+END;
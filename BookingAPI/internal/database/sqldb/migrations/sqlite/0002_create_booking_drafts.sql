@@ -0,0 +1,8 @@
+-- expires_at is in seconds since the epoch
+CREATE TABLE booking_drafts (
+    session_id TEXT    PRIMARY KEY,
+    expires_at INTEGER NOT NULL,
+    data       TEXT    NOT NULL
+);
+
+CREATE INDEX booking_drafts_expires_at_idx ON booking_drafts (expires_at);
@@ -0,0 +1,3 @@
+-- The blocks take the nights of a property offline like the bookings, so
+-- they are kept in the same table, where the overlap is already rejected.
+ALTER TABLE bookings ADD COLUMN kind TEXT NOT NULL DEFAULT 'booking' CHECK (kind IN ('booking', 'block'));
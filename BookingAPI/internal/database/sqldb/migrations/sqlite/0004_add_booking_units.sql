@@ -0,0 +1,31 @@
+-- unit_key identifies the unit of a room type a booking holds, empty for the
+-- property as a whole, and only the bookings of the same unit overlap.
+ALTER TABLE bookings ADD COLUMN unit_key TEXT NOT NULL DEFAULT '';
+
+DROP TRIGGER bookings_no_overlap_insert;
+DROP TRIGGER bookings_no_overlap_update;
+
+CREATE TRIGGER bookings_no_overlap_insert BEFORE INSERT ON bookings
+WHEN EXISTS (
+    SELECT 1 FROM bookings
+    WHERE property_id = NEW.property_id
+      AND unit_key = NEW.unit_key
+      AND start_date < NEW.end_date
+      AND end_date > NEW.start_date
+)
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap');
+END;
+
+CREATE TRIGGER bookings_no_overlap_update BEFORE UPDATE OF property_id, unit_key, start_date, end_date ON bookings
+WHEN EXISTS (
+    SELECT 1 FROM bookings
+    WHERE property_id = NEW.property_id
+      AND unit_key = NEW.unit_key
+      AND booking_id <> NEW.booking_id
+      AND start_date < NEW.end_date
+      AND end_date > NEW.start_date
+)
+BEGIN
+    SELECT RAISE(ABORT, 'bookings_no_overlap');
+END;
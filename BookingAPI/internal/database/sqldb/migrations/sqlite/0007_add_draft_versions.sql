@@ -0,0 +1,3 @@
+-- the drafts are put on the version they were read at, the drafts stored
+-- before counting as version 0
+ALTER TABLE booking_drafts ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
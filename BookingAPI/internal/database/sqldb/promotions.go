@@ -0,0 +1,119 @@
+package sqldb
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+// The promotions are kept in a table of their own, served by the bookings
+// store, which redeems them in the transaction adding the bookings and
+// releases the redemptions in the one removing them. The
+// redemptions are counted in their column, not in the data.
+
+func (store *bookingsStore) AddPromotion(ctx context.Context, promotion domain.Promotion) error {
+	data, err := json.Marshal(promotion)
+	if err != nil {
+		return &database.Error{Op: opMarshal, Table: promotionsTable, Err: err}
+	}
+
+	_, err = store.db.db.ExecContext(ctx, store.db.rebind(
+		"INSERT INTO promotions (code, max_redemptions, redemptions, data) VALUES (?, ?, ?, ?)"),
+		promotion.Code, promotion.MaxRedemptions, promotion.Redemptions, string(data))
+	if err != nil {
+		return store.db.newError("AddPromotion", promotionsTable, err)
+	}
+	return nil
+}
+
+func (store *bookingsStore) GetPromotion(ctx context.Context, code string) (*domain.Promotion, error) {
+	row := store.db.db.QueryRowContext(ctx, store.db.rebind(
+		"SELECT data, redemptions FROM promotions WHERE code = ?"), code)
+
+	return scanPromotion(store.db, row, "GetPromotion")
+}
+
+func (store *bookingsStore) GetPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	rows, err := store.db.db.QueryContext(ctx, "SELECT data, redemptions FROM promotions ORDER BY code")
+	if err != nil {
+		return nil, store.db.newError("GetPromotions", promotionsTable, err)
+	}
+	defer rows.Close()
+
+	var promotions []domain.Promotion
+	for rows.Next() {
+		promotion, err := scanPromotion(store.db, rows, "GetPromotions")
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, *promotion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, store.db.newError("GetPromotions", promotionsTable, err)
+	}
+	return promotions, nil
+}
+
+func (store *bookingsStore) RemovePromotion(ctx context.Context, code string) error {
+	result, err := store.db.db.ExecContext(ctx, store.db.rebind("DELETE FROM promotions WHERE code = ?"), code)
+	if err != nil {
+		return store.db.newError("RemovePromotion", promotionsTable, err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return store.db.newError("RemovePromotion", promotionsTable, err)
+	}
+	if removed == 0 {
+		return &database.Error{Op: "RemovePromotion", Table: promotionsTable, Kind: database.ErrNotFound}
+	}
+	return nil
+}
+
+// redeem counts a redemption of the promo code in the transaction, failing
+// with database.ErrLimitReached if the promotion is used up or gone.
+func (store *bookingsStore) redeem(ctx context.Context, tx *sql.Tx, code string) error {
+	result, err := tx.ExecContext(ctx, store.db.rebind(
+		`UPDATE promotions SET redemptions = redemptions + 1
+		WHERE code = ? AND (max_redemptions IS NULL OR redemptions < max_redemptions)`), code)
+	if err != nil {
+		return store.db.newError("AddBooking", promotionsTable, err)
+	}
+
+	redeemed, err := result.RowsAffected()
+	if err != nil {
+		return store.db.newError("AddBooking", promotionsTable, err)
+	}
+	if redeemed == 0 {
+		return &database.Error{Op: "AddBooking", Table: promotionsTable, Kind: database.ErrLimitReached}
+	}
+	return nil
+}
+
+// release takes back a redemption of the promo code in the transaction, if
+// the promotion is still there.
+func (store *bookingsStore) release(ctx context.Context, tx *sql.Tx, code string) error {
+	_, err := tx.ExecContext(ctx, store.db.rebind(
+		"UPDATE promotions SET redemptions = redemptions - 1 WHERE code = ? AND redemptions > 0"), code)
+	if err != nil {
+		return store.db.newError("RemoveBooking", promotionsTable, err)
+	}
+	return nil
+}
+
+func scanPromotion(db *DB, row scanner, op string) (*domain.Promotion, error) {
+	var data []byte
+	var redemptions int
+	if err := row.Scan(&data, &redemptions); err != nil {
+		return nil, db.newError(op, promotionsTable, err)
+	}
+
+	var promotion domain.Promotion
+	if err := json.Unmarshal(data, &promotion); err != nil {
+		return nil, &database.Error{Op: opUnmarshal, Table: promotionsTable, Err: err}
+	}
+	promotion.Redemptions = redemptions
+	return &promotion, nil
+}
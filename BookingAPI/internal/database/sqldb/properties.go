@@ -0,0 +1,92 @@
+package sqldb
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+type propertiesStore struct {
+	db *DB
+}
+
+func NewPropertiesStore(db *DB) *propertiesStore {
+	return &propertiesStore{db: db}
+}
+
+func (store *propertiesStore) PutProperty(ctx context.Context, property domain.Property) error {
+	data, err := json.Marshal(property)
+	if err != nil {
+		return &database.Error{Op: opMarshal, Table: propertiesTable, Err: err}
+	}
+
+	_, err = store.db.db.ExecContext(ctx, store.db.rebind(
+		`INSERT INTO properties (property_id, city, country, bedrooms, guests, data)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (property_id) DO UPDATE SET
+			city = excluded.city,
+			country = excluded.country,
+			bedrooms = excluded.bedrooms,
+			guests = excluded.guests,
+			data = excluded.data`),
+		property.PropertyId, property.City, property.Country, property.Bedrooms, property.Guests, string(data))
+	if err != nil {
+		return store.db.newError("PutProperty", propertiesTable, err)
+	}
+	return nil
+}
+
+func (store *propertiesStore) GetProperty(ctx context.Context, propertyId int) (*domain.Property, error) {
+	row := store.db.db.QueryRowContext(ctx, store.db.rebind(
+		"SELECT data FROM properties WHERE property_id = ?"), propertyId)
+
+	return scanData[domain.Property](store.db, row, "GetProperty", propertiesTable)
+}
+
+func (store *propertiesStore) Search(ctx context.Context, options domain.SearchOptions) ([]domain.Property, error) {
+	var conditions []string
+	var args []any
+	if options.City != nil {
+		conditions = append(conditions, "city = ?")
+		args = append(args, *options.City)
+	}
+	if options.Country != nil {
+		conditions = append(conditions, "country = ?")
+		args = append(args, *options.Country)
+	}
+	if options.Bedrooms != nil {
+		conditions = append(conditions, "bedrooms = ?")
+		args = append(args, *options.Bedrooms)
+	}
+	if options.Guests != nil {
+		conditions = append(conditions, "guests = ?")
+		args = append(args, *options.Guests)
+	}
+
+	query := "SELECT data FROM properties"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY property_id"
+
+	rows, err := store.db.db.QueryContext(ctx, store.db.rebind(query), args...)
+	if err != nil {
+		return nil, store.db.newError("Search", propertiesTable, err)
+	}
+	defer rows.Close()
+
+	var properties []domain.Property
+	for rows.Next() {
+		property, err := scanData[domain.Property](store.db, rows, "Search", propertiesTable)
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, *property)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, store.db.newError("Search", propertiesTable, err)
+	}
+	return properties, nil
+}
@@ -0,0 +1,172 @@
+// Package sqldb implements the stores on top of a SQL database, SQLite or
+// PostgreSQL, for the deployments that cannot use DynamoDB.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+// The drivers supported by Open.
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+const (
+	bookingsTable   = "bookings"
+	propertiesTable = "properties"
+	draftsTable     = "booking_drafts"
+	promotionsTable = "promotions"
+)
+
+//go:embed migrations
+var migrations embed.FS
+
+// DB is a database handle shared by the stores.
+type DB struct {
+	db      *sql.DB
+	dialect dialect
+}
+
+type dialect struct {
+	name       string
+	driverName string
+	// lock is run first in the migration transactions to serialize them.
+	lock     string
+	classify func(err error) error
+}
+
+var dialects = map[string]dialect{
+	DriverSQLite: {
+		name:       DriverSQLite,
+		driverName: "sqlite",
+		classify:   classifySQLite,
+	},
+	DriverPostgres: {
+		name:       DriverPostgres,
+		driverName: "pgx",
+		lock:       "SELECT pg_advisory_xact_lock(20240701)",
+		classify:   classifyPostgres,
+	},
+}
+
+// Open opens the database of the driver, one of DriverSQLite and
+// DriverPostgres. The dsn is a file name or a file: URI for SQLite and
+// a connection string for PostgreSQL.
+func Open(driver, dsn string) (*DB, error) {
+	dialect, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+
+	db, err := sql.Open(dialect.driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s database: %w", driver, err)
+	}
+	if driver == DriverSQLite {
+		// SQLite serializes the writes anyway, a single connection also
+		// keeps an in-memory database alive and shared by the stores.
+		db.SetMaxOpenConns(1)
+	}
+	return &DB{db: db, dialect: dialect}, nil
+}
+
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// Migrate applies the migrations not applied to the database yet, each in
+// its own transaction. The applied ones are recorded in schema_migrations.
+func (db *DB) Migrate(ctx context.Context) error {
+	_, err := db.db.ExecContext(ctx,
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)")
+	if err != nil {
+		return fmt.Errorf("unable to create schema_migrations: %w", err)
+	}
+
+	dir := path.Join("migrations", db.dialect.name)
+	entries, err := fs.ReadDir(migrations, dir)
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	for _, entry := range entries {
+		name := entry.Name()
+		version, err := strconv.Atoi(strings.SplitN(name, "_", 2)[0])
+		if err != nil {
+			return fmt.Errorf("migration %s has no version: %w", name, err)
+		}
+		script, err := migrations.ReadFile(path.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err := db.migrate(ctx, version, string(script)); err != nil {
+			return fmt.Errorf("unable to apply migration %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (db *DB) migrate(ctx context.Context, version int, script string) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if db.dialect.lock != "" {
+		if _, err := tx.ExecContext(ctx, db.dialect.lock); err != nil {
+			return err
+		}
+	}
+
+	var applied int
+	err = tx.QueryRowContext(ctx,
+		db.rebind("SELECT COUNT(*) FROM schema_migrations WHERE version = ?"), version).Scan(&applied)
+	if err != nil || applied > 0 {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		db.rebind("INSERT INTO schema_migrations (version) VALUES (?)"), version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// rebind replaces the ? placeholders of the query with the ones of the
+// dialect.
+func (db *DB) rebind(query string) string {
+	if db.dialect.name != DriverPostgres {
+		return query
+	}
+
+	var rebound strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			rebound.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		rebound.WriteRune(r)
+	}
+	return rebound.String()
+}
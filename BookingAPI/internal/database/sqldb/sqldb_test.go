@@ -0,0 +1,126 @@
+package sqldb_test
+
+import (
+	"booking/internal/database"
+	"booking/internal/database/databasetest"
+	"booking/internal/database/sqldb"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The PostgreSQL stores are tested only when POSTGRES_TEST_URL is set to the
+// connection string of a database the tests may wipe, the SQLite ones always.
+const envTestURL = "POSTGRES_TEST_URL"
+
+func TestSQLiteBookingsStore(t *testing.T) {
+	databasetest.TestBookingsStore(t, func(t *testing.T) database.BookingsStore {
+		return sqldb.NewBookingsStore(openSQLite(t))
+	})
+}
+
+func TestSQLiteBlocksStore(t *testing.T) {
+	databasetest.TestBlocksStore(t, func(t *testing.T) (database.BookingsStore, database.BlocksStore) {
+		store := sqldb.NewBookingsStore(openSQLite(t))
+		return store, store
+	})
+}
+
+func TestSQLitePromotionsStore(t *testing.T) {
+	databasetest.TestPromotionsStore(t, func(t *testing.T) (database.BookingsStore, database.PromotionsStore) {
+		store := sqldb.NewBookingsStore(openSQLite(t))
+		return store, store
+	})
+}
+
+func TestSQLitePropertiesStore(t *testing.T) {
+	databasetest.TestPropertiesStore(t, func(t *testing.T) database.PropertiesStore {
+		return sqldb.NewPropertiesStore(openSQLite(t))
+	})
+}
+
+func TestSQLiteDraftsStore(t *testing.T) {
+	databasetest.TestDraftsStore(t, func(t *testing.T) database.DraftsStore {
+		return sqldb.NewDraftsStore(openSQLite(t))
+	})
+}
+
+func TestPostgresBookingsStore(t *testing.T) {
+	url := postgresURL(t)
+	databasetest.TestBookingsStore(t, func(t *testing.T) database.BookingsStore {
+		return sqldb.NewBookingsStore(openPostgres(t, url))
+	})
+}
+
+func TestPostgresBlocksStore(t *testing.T) {
+	url := postgresURL(t)
+	databasetest.TestBlocksStore(t, func(t *testing.T) (database.BookingsStore, database.BlocksStore) {
+		store := sqldb.NewBookingsStore(openPostgres(t, url))
+		return store, store
+	})
+}
+
+func TestPostgresPromotionsStore(t *testing.T) {
+	url := postgresURL(t)
+	databasetest.TestPromotionsStore(t, func(t *testing.T) (database.BookingsStore, database.PromotionsStore) {
+		store := sqldb.NewBookingsStore(openPostgres(t, url))
+		return store, store
+	})
+}
+
+func TestPostgresPropertiesStore(t *testing.T) {
+	url := postgresURL(t)
+	databasetest.TestPropertiesStore(t, func(t *testing.T) database.PropertiesStore {
+		return sqldb.NewPropertiesStore(openPostgres(t, url))
+	})
+}
+
+func TestPostgresDraftsStore(t *testing.T) {
+	url := postgresURL(t)
+	databasetest.TestDraftsStore(t, func(t *testing.T) database.DraftsStore {
+		return sqldb.NewDraftsStore(openPostgres(t, url))
+	})
+}
+
+func TestMigrateTwice(t *testing.T) {
+	db := openSQLite(t)
+	if err := db.Migrate(context.Background()); err != nil {
+		t.Errorf("Migrate() error = %v", err)
+	}
+}
+
+func openSQLite(t *testing.T) *sqldb.DB {
+	return open(t, sqldb.DriverSQLite, filepath.Join(t.TempDir(), "booking.db"))
+}
+
+func postgresURL(t *testing.T) string {
+	url := os.Getenv(envTestURL)
+	if url == "" {
+		t.Skipf("%s is not set", envTestURL)
+	}
+	return url
+}
+
+// openPostgres opens the database with the tables emptied.
+func openPostgres(t *testing.T, url string) *sqldb.DB {
+	db := open(t, sqldb.DriverPostgres, url)
+	if err := db.Truncate(context.Background()); err != nil {
+		t.Fatalf("Truncate() error = %v", err)
+	}
+	return db
+}
+
+func open(t *testing.T, driver, dsn string) *sqldb.DB {
+	t.Helper()
+	db, err := sqldb.Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := db.Migrate(context.Background()); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	return db
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"booking/internal/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+// BookingsStore is implemented by every storage backend of the bookings.
+//
+// AddBooking fails with ErrConditionalCheckFailed when the booking ID is
+// taken and with ErrOverlap when any of the nights is blocked or booked for
+// a unit overlapping the unit of the booking, see UnitsOverlap. A booking with a promo code redeems
+// it along with being added, both or neither of them, failing with
+// ErrLimitReached when the promotion is used up or gone. RemoveBooking
+// releases the redemption along with removing the booking, if the promotion
+// is still there. GetBooking and RemoveBooking fail with ErrNotFound when
+// there is no such booking.
+type BookingsStore interface {
+	AddBooking(ctx context.Context, booking domain.Booking) error
+	GetBookingsForProperty(ctx context.Context, propertyId int) ([]domain.Booking, error)
+	GetBooking(ctx context.Context, bookingId string) (*domain.Booking, error)
+	RemoveBooking(ctx context.Context, bookingId string) error
+}
+
+// BlocksStore is implemented by every storage backend of the blocks, which
+// share the nights with the bookings of the same backend. A block holds the
+// property as a whole, as the bookings without a unit do, so it overlaps the
+// bookings of all the units.
+//
+// AddBlock fails with ErrConditionalCheckFailed when the block ID is taken
+// and with ErrOverlap when any of the nights is booked or blocked already.
+// RemoveBlock fails with ErrNotFound when there is no such block.
+type BlocksStore interface {
+	AddBlock(ctx context.Context, block domain.Block) error
+	GetBlocksForProperty(ctx context.Context, propertyId int) ([]domain.Block, error)
+	RemoveBlock(ctx context.Context, blockId string) error
+}
+
+// PromotionsStore is implemented by every storage backend of the
+// promotions, which the bookings of the same backend redeem.
+//
+// AddPromotion fails with ErrConditionalCheckFailed when the code is taken.
+// GetPromotion and RemovePromotion fail with ErrNotFound when there is no
+// such promotion. GetPromotions returns them sorted by code. The codes are
+// matched as they are, the services keep them upper case.
+type PromotionsStore interface {
+	AddPromotion(ctx context.Context, promotion domain.Promotion) error
+	GetPromotion(ctx context.Context, code string) (*domain.Promotion, error)
+	GetPromotions(ctx context.Context) ([]domain.Promotion, error)
+	RemovePromotion(ctx context.Context, code string) error
+}
+
+// PropertiesStore is implemented by every storage backend of the properties.
+//
+// GetProperty fails with ErrNotFound when there is no such property.
+// Search matches the given options exactly, ignoring the unset ones and the
+// dates of the stay, which are left to the bookings.
+type PropertiesStore interface {
+	PutProperty(ctx context.Context, property domain.Property) error
+	GetProperty(ctx context.Context, propertyId int) (*domain.Property, error)
+	Search(ctx context.Context, options domain.SearchOptions) ([]domain.Property, error)
+}
+
+// DraftsStore is implemented by every storage backend of the booking drafts.
+//
+// PutDraft replaces the draft of the session with the next version of it,
+// failing with ErrConditionalCheckFailed unless the stored draft still has
+// the version of the draft put, a version 0 standing for no draft or only an
+// expired one. GetDraft and RemoveDraft fail with ErrNotFound when the
+// session has no draft or only an expired one, which the backends may keep
+// until they get round to removing it.
+type DraftsStore interface {
+	PutDraft(ctx context.Context, draft domain.Draft) error
+	GetDraft(ctx context.Context, sessionId string) (*domain.Draft, error)
+	RemoveDraft(ctx context.Context, sessionId string) error
+}
+
+// UnitKey identifies the unit the booking holds the nights of: its room type
+// and unit, or an empty string for a property booked as a whole.
+func UnitKey(booking domain.Booking) string {
+	if booking.RoomTypeId == nil || booking.Unit == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s#%d", *booking.RoomTypeId, *booking.Unit)
+}
+
+// UnitsOverlap tells whether the bookings of the units, see UnitKey, may not
+// share a night: they are of the same unit or either of them holds the
+// property as a whole.
+func UnitsOverlap(unitKey1, unitKey2 string) bool {
+	return unitKey1 == unitKey2 || unitKey1 == "" || unitKey2 == ""
+}
+
+// Nights returns the nights a booking spans, from its start date up to,
+// but excluding, its end date.
+func Nights(booking domain.Booking) []time.Time {
+	return NightsBetween(booking.StartDate.Time, booking.EndDate.Time)
+}
+
+// NightsBetween returns the nights from the start date up to, but
+// excluding, the end date.
+func NightsBetween(startDate, endDate time.Time) []time.Time {
+	var nights []time.Time
+	for night := startDate; night.Before(endDate); night = night.AddDate(0, 0, 1) {
+		nights = append(nights, night)
+	}
+	return nights
+}
@@ -4,80 +4,693 @@
 package domain
 
 import (
+	"time"
+
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for RuleViolationRule.
+const (
+	ArrivalWeekday RuleViolationRule = "arrival_weekday"
+	BookingHorizon RuleViolationRule = "booking_horizon"
+	LeadTime       RuleViolationRule = "lead_time"
+	MaxNights      RuleViolationRule = "max_nights"
+	MinNights      RuleViolationRule = "min_nights"
+	SameDayCutoff  RuleViolationRule = "same_day_cutoff"
+	StartInPast    RuleViolationRule = "start_in_past"
+)
+
+// Defines values for Weekday.
+const (
+	Friday    Weekday = "Friday"
+	Monday    Weekday = "Monday"
+	Saturday  Weekday = "Saturday"
+	Sunday    Weekday = "Sunday"
+	Thursday  Weekday = "Thursday"
+	Tuesday   Weekday = "Tuesday"
+	Wednesday Weekday = "Wednesday"
+)
+
+// Defines values for Format.
+const (
+	FormatAgent Format = "agent"
+	FormatJson  Format = "json"
+)
+
+// Defines values for SubmitBookingDraftParamsFormat.
+const (
+	SubmitBookingDraftParamsFormatAgent SubmitBookingDraftParamsFormat = "agent"
+	SubmitBookingDraftParamsFormatJson  SubmitBookingDraftParamsFormat = "json"
+)
+
+// Defines values for BookPropertyParamsFormat.
+const (
+	BookPropertyParamsFormatAgent BookPropertyParamsFormat = "agent"
+	BookPropertyParamsFormatJson  BookPropertyParamsFormat = "json"
+)
+
+// Defines values for SearchPropertiesParamsFormat.
+const (
+	SearchPropertiesParamsFormatAgent SearchPropertiesParamsFormat = "agent"
+	SearchPropertiesParamsFormatJson  SearchPropertiesParamsFormat = "json"
+)
+
+// Defines values for GetPropertyParamsFormat.
+const (
+	GetPropertyParamsFormatAgent GetPropertyParamsFormat = "agent"
+	GetPropertyParamsFormatJson  GetPropertyParamsFormat = "json"
+)
+
+// Defines values for GetAvailabilityParamsFormat.
+const (
+	GetAvailabilityParamsFormatAgent GetAvailabilityParamsFormat = "agent"
+	GetAvailabilityParamsFormatJson  GetAvailabilityParamsFormat = "json"
+)
+
+// AgentSummary Compact summary of a response, with only the details relevant to decide, for agents to read back instead of the full resources.
+type AgentSummary struct {
+	// Items A sentence per result of a search or per room type of an availability check.
+	Items   *[]AgentSummaryItem `json:"items,omitempty"`
+	Summary string              `json:"summary"`
+}
+
+// AgentSummaryItem defines model for AgentSummaryItem.
+type AgentSummaryItem struct {
+	// Id ID of the result, to refer to it in the following calls.
+	Id   string `json:"id"`
+	Text string `json:"text"`
+}
+
 // Availability defines model for Availability.
 type Availability struct {
-	Available bool    `json:"available"`
-	Price     float32 `json:"price"`
+	Available bool `json:"available"`
+
+	// Discount Discount of the promo code taken off the price, all the prices being with the discount taken off.
+	Discount *float32 `json:"discount,omitempty"`
+	Price    float32  `json:"price"`
+
+	// RatePlans Prices of the available stay with the rate plans of the property, the given one or all of them. The price and the room types above are of the given rate plan or else of the standard rate.
+	RatePlans *[]RatePlanQuote `json:"ratePlans,omitempty"`
+
+	// RoomTypes Availability of the room types hosting the guests, for a property with room types. The stay is available if any of them is, at the lowest of their prices.
+	RoomTypes *[]RoomTypeAvailability `json:"roomTypes,omitempty"`
+
+	// Violations Stay rules of the property the stay breaks, if it is not available for them.
+	Violations *[]RuleViolation `json:"violations,omitempty"`
+}
+
+// Block defines model for Block.
+type Block struct {
+	BlockId openapi_types.UUID `json:"blockId"`
+
+	// EndDate Day after the last night of the block, as for the bookings.
+	EndDate    openapi_types.Date `json:"endDate"`
+	PropertyId int                `json:"propertyId"`
+	Reason     string             `json:"reason"`
+
+	// StartDate First night of the block.
+	StartDate openapi_types.Date `json:"startDate"`
+}
+
+// BlockRequest defines model for BlockRequest.
+type BlockRequest struct {
+	// EndDate Day after the last night of the block, as for the bookings.
+	EndDate openapi_types.Date `json:"endDate"`
+	Reason  string             `json:"reason"`
+
+	// StartDate First night of the block.
+	StartDate openapi_types.Date `json:"startDate"`
+}
+
+// BookingDraft Booking request collected over the turns of an agent session.
+type BookingDraft struct {
+	// Complete Whether the draft can be submitted, with no field missing or invalid.
+	Complete bool `json:"complete"`
+
+	// ExpiresAt When the draft is discarded unless it is updated again.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Fields Fields of a booking request, any of them, set as the customer gives them.
+	Fields BookingDraftFields `json:"fields"`
+
+	// Invalid Fields set to values that are not valid.
+	Invalid []FieldError `json:"invalid"`
+
+	// Missing Fields still to be set before the draft can be submitted.
+	Missing   []string `json:"missing"`
+	SessionId string   `json:"sessionId"`
+}
+
+// BookingDraftFields Fields of a booking request, any of them, set as the customer gives them.
+type BookingDraftFields struct {
+	ContactDetails *ContactDetails     `json:"contactDetails,omitempty"`
+	CustomerName   *string             `json:"customerName,omitempty"`
+	EndDate        *openapi_types.Date `json:"endDate,omitempty"`
+
+	// Guests Who stays at the property, 1 adult by default.
+	Guests *Guests `json:"guests,omitempty"`
+
+	// PaymentInformation Payment card of a booking draft. The CVV is not kept in the draft, it is given when the draft is submitted.
+	PaymentInformation *DraftPaymentInformation `json:"paymentInformation,omitempty"`
+	PromoCode          *string                  `json:"promoCode,omitempty"`
+	PropertyId         *int                     `json:"propertyId,omitempty"`
+	RatePlanId         *string                  `json:"ratePlanId,omitempty"`
+	RoomTypeId         *string                  `json:"roomTypeId,omitempty"`
+	StartDate          *openapi_types.Date      `json:"startDate,omitempty"`
+}
+
+// BookingDraftSubmission Details of the booking given only when the draft is submitted, never kept.
+type BookingDraftSubmission struct {
+	// Cvv CVV of the payment card.
+	Cvv string `json:"cvv"`
 }
 
 // BookingRequest defines model for BookingRequest.
 type BookingRequest struct {
-	ContactDetails struct {
-		Email *string `json:"email,omitempty"`
-		Phone *string `json:"phone,omitempty"`
-	} `json:"contactDetails"`
-	CustomerName       string             `json:"customerName"`
-	EndDate            openapi_types.Date `json:"endDate"`
-	PaymentInformation struct {
-		CardNumber *string `json:"cardNumber,omitempty"`
-		Cvv        *string `json:"cvv,omitempty"`
-		ExpiryDate *string `json:"expiryDate,omitempty"`
-	} `json:"paymentInformation"`
-	PropertyId int                `json:"propertyId"`
+	ContactDetails ContactDetails     `json:"contactDetails"`
+	CustomerName   string             `json:"customerName"`
+	EndDate        openapi_types.Date `json:"endDate"`
+
+	// Guests Who stays at the property, 1 adult by default.
+	Guests             *Guests            `json:"guests,omitempty"`
+	PaymentInformation PaymentInformation `json:"paymentInformation"`
+
+	// PromoCode Promo code to redeem for a discount.
+	PromoCode  *string `json:"promoCode,omitempty"`
+	PropertyId int     `json:"propertyId"`
+
+	// RatePlanId Rate plan to book with, the standard rate by default.
+	RatePlanId *string `json:"ratePlanId,omitempty"`
+
+	// RoomTypeId Room type to book, for a property with room types. By default the first room type with a unit free for the stay and hosting the guests.
+	RoomTypeId *string            `json:"roomTypeId,omitempty"`
 	StartDate  openapi_types.Date `json:"startDate"`
 }
 
 // BookingResponse defines model for BookingResponse.
 type BookingResponse struct {
-	BookingId           openapi_types.UUID `json:"bookingId"`
+	BookingId openapi_types.UUID `json:"bookingId"`
+
+	// Cancellation Whether and until when the booking may be cancelled.
+	Cancellation        *CancellationTerms `json:"cancellation,omitempty"`
 	CheckInInstructions *string            `json:"checkInInstructions,omitempty"`
 	CustomerName        string             `json:"customerName"`
-	EndDate             openapi_types.Date `json:"endDate"`
-	PropertyId          int                `json:"propertyId"`
-	StartDate           openapi_types.Date `json:"startDate"`
-	TotalAmount         float32            `json:"totalAmount"`
+
+	// Discount Discount of the promo code, taken off the total amount.
+	Discount *float32           `json:"discount,omitempty"`
+	EndDate  openapi_types.Date `json:"endDate"`
+
+	// Guests Who stays at the property, 1 adult by default.
+	Guests *Guests `json:"guests,omitempty"`
+
+	// PromoCode Promo code redeemed.
+	PromoCode  *string `json:"promoCode,omitempty"`
+	PropertyId int     `json:"propertyId"`
+
+	// RatePlanId Rate plan booked with, none for the standard rate.
+	RatePlanId *string `json:"ratePlanId,omitempty"`
+
+	// RoomTypeId Room type booked, for a property with room types.
+	RoomTypeId  *string            `json:"roomTypeId,omitempty"`
+	StartDate   openapi_types.Date `json:"startDate"`
+	TotalAmount float32            `json:"totalAmount"`
+
+	// Unit Unit of the room type allocated to the booking, numbered from 1.
+	Unit *int `json:"unit,omitempty"`
+}
+
+// CancellationPolicy defines model for CancellationPolicy.
+type CancellationPolicy struct {
+	// DeadlineDays Days before the check-in a refundable booking may be cancelled until, 0 by default, up to the check-in.
+	DeadlineDays *int `json:"deadlineDays,omitempty"`
+
+	// Refundable Whether the bookings may be cancelled at all.
+	Refundable bool `json:"refundable"`
+}
+
+// CancellationTerms Whether and until when the booking may be cancelled.
+type CancellationTerms struct {
+	// CancellableUntil Time in the time zone of the property a refundable booking may be cancelled until.
+	CancellableUntil *time.Time `json:"cancellableUntil,omitempty"`
+
+	// Refundable Whether the booking may be cancelled, as it is now.
+	Refundable bool `json:"refundable"`
+}
+
+// ContactDetails defines model for ContactDetails.
+type ContactDetails struct {
+	Email *string `json:"email,omitempty"`
+	Phone *string `json:"phone,omitempty"`
+}
+
+// DateResolution defines model for DateResolution.
+type DateResolution struct {
+	EndDate openapi_types.Date `json:"endDate"`
+
+	// Explanation How the expression was read, to confirm the dates with the customer.
+	Explanation string `json:"explanation"`
+	Nights      int    `json:"nights"`
+
+	// ReferenceDate The date the expression was resolved against.
+	ReferenceDate openapi_types.Date `json:"referenceDate"`
+	StartDate     openapi_types.Date `json:"startDate"`
+	TimeZone      string             `json:"timeZone"`
+}
+
+// DraftPaymentInformation Payment card of a booking draft. The CVV is not kept in the draft, it is given when the draft is submitted.
+type DraftPaymentInformation struct {
+	// CardNumber Card number, masked save for its last four digits in the drafts returned.
+	CardNumber *string `json:"cardNumber,omitempty"`
+
+	// ExpiryDate Expiry date in the MM/YY format, masked in the drafts returned.
+	ExpiryDate *string `json:"expiryDate,omitempty"`
+}
+
+// DynamicPricing Nightly rates following the demand, the static base rate without it. The base rate of a night goes up or down with the recent occupancy of the property and its city, goes up on Friday and Saturday nights and down for the nights close to today, within the floor and the ceiling. The fees and the rate plans apply on top of it.
+type DynamicPricing struct {
+	// CeilingPercent Highest nightly rate, in percent of the base rate.
+	CeilingPercent int `json:"ceilingPercent"`
+
+	// FloorPercent Lowest nightly rate, in percent of the base rate.
+	FloorPercent int `json:"floorPercent"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Guests Who stays at the property, 1 adult by default.
+type Guests struct {
+	Adults *int `json:"adults,omitempty"`
+
+	// Children Children aged 2 to 17, counted against the capacity like the adults.
+	Children *int `json:"children,omitempty"`
+
+	// Infants Children under 2, not counted against the capacity.
+	Infants *int `json:"infants,omitempty"`
+	Pets    *int `json:"pets,omitempty"`
+}
+
+// PaymentInformation defines model for PaymentInformation.
+type PaymentInformation struct {
+	CardNumber *string `json:"cardNumber,omitempty"`
+	Cvv        *string `json:"cvv,omitempty"`
+	ExpiryDate *string `json:"expiryDate,omitempty"`
+}
+
+// Problem Problem details as defined by RFC 7807.
+type Problem struct {
+	// Code Stable, machine readable error code.
+	Code string `json:"code"`
+
+	// Detail Explanation specific to this occurrence of the problem.
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Fields of the request that are not valid.
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Path of the request that caused the problem.
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP status code of the response.
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type.
+	Title string `json:"title"`
+
+	// Type URI identifying the problem type.
+	Type string `json:"type"`
+
+	// Violations Stay rules of the property the requested stay breaks.
+	Violations *[]RuleViolation `json:"violations,omitempty"`
+}
+
+// Promotion defines model for Promotion.
+type Promotion struct {
+	// Cities Cities of the properties the promo code applies to. It applies to all the properties if neither the properties nor the cities are set.
+	Cities *[]string `json:"cities,omitempty"`
+
+	// Code The promo code, matched regardless of the case.
+	Code        string  `json:"code"`
+	Description *string `json:"description,omitempty"`
+
+	// DiscountAmount Amount taken off the price of the stay, at most all of it.
+	DiscountAmount *float32 `json:"discountAmount,omitempty"`
+
+	// DiscountPercent Percent taken off the price of the stay.
+	DiscountPercent *float32 `json:"discountPercent,omitempty"`
+
+	// MaxRedemptions Times the promo code may be redeemed, with no limit by default.
+	MaxRedemptions *int `json:"maxRedemptions,omitempty"`
+
+	// MinNights Shortest stay the promo code applies to.
+	MinNights *int `json:"minNights,omitempty"`
+
+	// PropertyIds Properties the promo code applies to, along with the ones in the cities.
+	PropertyIds *[]int `json:"propertyIds,omitempty"`
+
+	// Redemptions Times the promo code has been redeemed.
+	Redemptions int `json:"redemptions"`
+
+	// ValidFrom When the promo code may be redeemed from, at once by default.
+	ValidFrom *time.Time `json:"validFrom,omitempty"`
+
+	// ValidUntil When the promo code expires, never by default.
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+}
+
+// PromotionRequest A promo code with either a percentage or a fixed discount, applying to the bookings made in its validity window, of the properties and cities in its scope and of at least its minimum nights, until it is redeemed the maximum times.
+type PromotionRequest struct {
+	// Cities Cities of the properties the promo code applies to. It applies to all the properties if neither the properties nor the cities are set.
+	Cities *[]string `json:"cities,omitempty"`
+
+	// Code The promo code, matched regardless of the case.
+	Code        string  `json:"code"`
+	Description *string `json:"description,omitempty"`
+
+	// DiscountAmount Amount taken off the price of the stay, at most all of it.
+	DiscountAmount *float32 `json:"discountAmount,omitempty"`
+
+	// DiscountPercent Percent taken off the price of the stay.
+	DiscountPercent *float32 `json:"discountPercent,omitempty"`
+
+	// MaxRedemptions Times the promo code may be redeemed, with no limit by default.
+	MaxRedemptions *int `json:"maxRedemptions,omitempty"`
+
+	// MinNights Shortest stay the promo code applies to.
+	MinNights *int `json:"minNights,omitempty"`
+
+	// PropertyIds Properties the promo code applies to, along with the ones in the cities.
+	PropertyIds *[]int `json:"propertyIds,omitempty"`
+
+	// ValidFrom When the promo code may be redeemed from, at once by default.
+	ValidFrom *time.Time `json:"validFrom,omitempty"`
+
+	// ValidUntil When the promo code expires, never by default.
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
 }
 
 // Property defines model for Property.
 type Property struct {
-	AccessInstructions        *string `json:"accessInstructions,omitempty"`
-	Address                   string  `json:"address"`
-	ArchitecturalStyle        *string `json:"architecturalStyle,omitempty"`
-	Bedrooms                  int     `json:"bedrooms"`
-	City                      string  `json:"city"`
-	Country                   string  `json:"country"`
-	EmergencyInstructions     *string `json:"emergencyInstructions,omitempty"`
-	FeatureDescription        *string `json:"featureDescription,omitempty"`
-	Guests                    int     `json:"guests"`
-	Layout                    *string `json:"layout,omitempty"`
-	Location                  string  `json:"location"`
-	PropertyId                int     `json:"propertyId"`
-	RecommendationDescription *string `json:"recommendationDescription,omitempty"`
-	RuleDescription           *string `json:"ruleDescription,omitempty"`
-	SecurityDescription       *string `json:"securityDescription,omitempty"`
-	Size                      int     `json:"size"`
-	Utilities                 *string `json:"utilities,omitempty"`
+	AccessInstructions *string `json:"accessInstructions,omitempty"`
+	Address            string  `json:"address"`
+	ArchitecturalStyle *string `json:"architecturalStyle,omitempty"`
+	Bedrooms           int     `json:"bedrooms"`
+
+	// BufferNightsAfter Nights left free after every stay, e.g. for the cleaning, none by default. The booked stays keep them free of the new ones and the other way round.
+	BufferNightsAfter *int `json:"bufferNightsAfter,omitempty"`
+
+	// BufferNightsBefore Nights left free before every stay, e.g. to prepare the property, none by default. The booked stays keep them free of the new ones and the other way round.
+	BufferNightsBefore *int `json:"bufferNightsBefore,omitempty"`
+
+	// CheckInTime Local time the guests may check in from, 15:00 by default.
+	CheckInTime *string `json:"checkInTime,omitempty"`
+
+	// CheckOutTime Local time the guests check out by, 11:00 by default. A stay may start on the day another one ends if the check-out is before the check-in.
+	CheckOutTime *string `json:"checkOutTime,omitempty"`
+	City         string  `json:"city"`
+	Country      string  `json:"country"`
+
+	// DynamicPricing Nightly rates following the demand, the static base rate without it. The base rate of a night goes up or down with the recent occupancy of the property and its city, goes up on Friday and Saturday nights and down for the nights close to today, within the floor and the ceiling. The fees and the rate plans apply on top of it.
+	DynamicPricing        *DynamicPricing `json:"dynamicPricing,omitempty"`
+	EmergencyInstructions *string         `json:"emergencyInstructions,omitempty"`
+
+	// ExtraGuestFee Fee per night for each adult or child beyond the included guests.
+	ExtraGuestFee      *float32 `json:"extraGuestFee,omitempty"`
+	FeatureDescription *string  `json:"featureDescription,omitempty"`
+	Guests             int      `json:"guests"`
+
+	// IncludedGuests Guests the base price covers, all the property hosts by default.
+	IncludedGuests *int    `json:"includedGuests,omitempty"`
+	Layout         *string `json:"layout,omitempty"`
+	Location       string  `json:"location"`
+
+	// MaxPets Pets allowed at the property, none by default.
+	MaxPets *int `json:"maxPets,omitempty"`
+
+	// PetFee Fee per night for each pet.
+	PetFee     *float32 `json:"petFee,omitempty"`
+	PropertyId int      `json:"propertyId"`
+
+	// RatePlans Rate plans the stays may be booked with, each with its price and cancellation terms, e.g. a non-refundable or a breakfast included rate. Only the standard rate, the base price with free cancellation, is offered without them.
+	RatePlans                 *[]RatePlan `json:"ratePlans,omitempty"`
+	RecommendationDescription *string     `json:"recommendationDescription,omitempty"`
+
+	// RoomTypes Room types of a property with several units, e.g. the rooms of a hotel, each booked on its own. A property without room types is booked as a whole.
+	RoomTypes           *[]RoomType `json:"roomTypes,omitempty"`
+	RuleDescription     *string     `json:"ruleDescription,omitempty"`
+	SecurityDescription *string     `json:"securityDescription,omitempty"`
+	Size                int         `json:"size"`
+
+	// StayRules Rules the stays at the property follow, on top of not starting before today. The ones not set take their defaults.
+	StayRules *StayRules `json:"stayRules,omitempty"`
+
+	// TimeZone IANA time zone of the property, UTC by default. The dates of the stays are days in it.
+	TimeZone  *string `json:"timeZone,omitempty"`
+	Utilities *string `json:"utilities,omitempty"`
+}
+
+// RatePlan defines model for RatePlan.
+type RatePlan struct {
+	CancellationPolicy CancellationPolicy `json:"cancellationPolicy"`
+	Description        *string            `json:"description,omitempty"`
+
+	// GuestNightFee Fee per night for each adult or child, e.g. for the breakfast, none by default.
+	GuestNightFee *float32 `json:"guestNightFee,omitempty"`
+	Name          string   `json:"name"`
+
+	// PriceAdjustmentPercent Percent the price of a night with the guest and pet fees changes by, e.g. -10 for a tenth off, none by default.
+	PriceAdjustmentPercent *float32 `json:"priceAdjustmentPercent,omitempty"`
+	RatePlanId             string   `json:"ratePlanId"`
+
+	// RoomTypeIds Room types the rate plan is offered for, all of them by default.
+	RoomTypeIds *[]string `json:"roomTypeIds,omitempty"`
+}
+
+// RatePlanQuote defines model for RatePlanQuote.
+type RatePlanQuote struct {
+	// Cancellation Whether and until when the booking may be cancelled.
+	Cancellation CancellationTerms `json:"cancellation"`
+	Name         string            `json:"name"`
+
+	// Price Lowest price of the stay with the rate plan, of the room types it is offered for.
+	Price      float32 `json:"price"`
+	RatePlanId string  `json:"ratePlanId"`
+}
+
+// RoomType defines model for RoomType.
+type RoomType struct {
+	// Guests Guests a unit hosts, in place of the ones of the property.
+	Guests     int    `json:"guests"`
+	Name       string `json:"name"`
+	RoomTypeId string `json:"roomTypeId"`
+
+	// Size Size of a unit, in place of the one of the property.
+	Size int `json:"size"`
+
+	// Units Units of the room type, e.g. the rooms, which can be booked for the same nights.
+	Units int `json:"units"`
+}
+
+// RoomTypeAvailability defines model for RoomTypeAvailability.
+type RoomTypeAvailability struct {
+	Available  bool    `json:"available"`
+	Name       string  `json:"name"`
+	Price      float32 `json:"price"`
+	RoomTypeId string  `json:"roomTypeId"`
+
+	// UnitsAvailable Units of the room type free for the whole stay.
+	UnitsAvailable int `json:"unitsAvailable"`
+}
+
+// RuleViolation A stay rule of the property the requested stay breaks.
+type RuleViolation struct {
+	// Field Field of the request to change to follow the rule.
+	Field   string            `json:"field"`
+	Message string            `json:"message"`
+	Rule    RuleViolationRule `json:"rule"`
 }
 
+// RuleViolationRule defines model for RuleViolation.Rule.
+type RuleViolationRule string
+
 // SearchOptions defines model for SearchOptions.
 type SearchOptions struct {
 	Bedrooms *int    `json:"bedrooms,omitempty"`
 	City     *string `json:"city,omitempty"`
 	Country  *string `json:"country,omitempty"`
-	Guests   *int    `json:"guests,omitempty"`
+
+	// EndDate End of the stay the properties should be available for, along with the startDate.
+	EndDate *openapi_types.Date `json:"endDate,omitempty"`
+	Guests  *int                `json:"guests,omitempty"`
+
+	// StartDate Start of the stay the properties should be available for, along with the endDate.
+	StartDate *openapi_types.Date `json:"startDate,omitempty"`
+}
+
+// StayRules Rules the stays at the property follow, on top of not starting before today. The ones not set take their defaults.
+type StayRules struct {
+	// ArrivalWeekdays Weekdays a stay may start on, any by default.
+	ArrivalWeekdays *[]Weekday `json:"arrivalWeekdays,omitempty"`
+
+	// BookingHorizonDays How many days ahead a stay may start at most, 365 by default.
+	BookingHorizonDays *int `json:"bookingHorizonDays,omitempty"`
+
+	// LeadTimeDays How many days ahead a stay must be booked at least, none by default.
+	LeadTimeDays *int `json:"leadTimeDays,omitempty"`
+
+	// MaxNights Longest stay, 30 nights by default and 98 at most.
+	MaxNights *int `json:"maxNights,omitempty"`
+
+	// MinNights Shortest stay, 1 night by default.
+	MinNights *int `json:"minNights,omitempty"`
+
+	// SameDayCutoff Local time until which a stay starting today may be booked, until the end of the day by default.
+	SameDayCutoff *string `json:"sameDayCutoff,omitempty"`
 }
 
-// GetPropertiesPropertyIdAvailabilityParams defines parameters for GetPropertiesPropertyIdAvailability.
-type GetPropertiesPropertyIdAvailabilityParams struct {
+// Weekday defines model for Weekday.
+type Weekday string
+
+// Format defines model for Format.
+type Format string
+
+// SessionId defines model for SessionId.
+type SessionId = string
+
+// BadRequest Problem details as defined by RFC 7807.
+type BadRequest = Problem
+
+// Conflict Problem details as defined by RFC 7807.
+type Conflict = Problem
+
+// NotFound Problem details as defined by RFC 7807.
+type NotFound = Problem
+
+// ServerError Problem details as defined by RFC 7807.
+type ServerError = Problem
+
+// DiscardBookingDraftParams defines parameters for DiscardBookingDraft.
+type DiscardBookingDraftParams struct {
+	// XAgentSessionId ID of the agent session the booking draft belongs to, set by the action group function from the session of the agent.
+	XAgentSessionId SessionId `json:"X-Agent-Session-Id"`
+}
+
+// GetBookingDraftParams defines parameters for GetBookingDraft.
+type GetBookingDraftParams struct {
+	// XAgentSessionId ID of the agent session the booking draft belongs to, set by the action group function from the session of the agent.
+	XAgentSessionId SessionId `json:"X-Agent-Session-Id"`
+}
+
+// UpdateBookingDraftParams defines parameters for UpdateBookingDraft.
+type UpdateBookingDraftParams struct {
+	// XAgentSessionId ID of the agent session the booking draft belongs to, set by the action group function from the session of the agent.
+	XAgentSessionId SessionId `json:"X-Agent-Session-Id"`
+}
+
+// SubmitBookingDraftParams defines parameters for SubmitBookingDraft.
+type SubmitBookingDraftParams struct {
+	// Format Set to agent for a compact summary with a sentence per result, in the application/vnd.booking.agent+json media type, instead of the full resources.
+	Format *SubmitBookingDraftParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// XAgentSessionId ID of the agent session the booking draft belongs to, set by the action group function from the session of the agent.
+	XAgentSessionId SessionId `json:"X-Agent-Session-Id"`
+}
+
+// SubmitBookingDraftParamsFormat defines parameters for SubmitBookingDraft.
+type SubmitBookingDraftParamsFormat string
+
+// BookPropertyParams defines parameters for BookProperty.
+type BookPropertyParams struct {
+	// Format Set to agent for a compact summary with a sentence per result, in the application/vnd.booking.agent+json media type, instead of the full resources.
+	Format *BookPropertyParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// BookPropertyParamsFormat defines parameters for BookProperty.
+type BookPropertyParamsFormat string
+
+// ResolveDatesParams defines parameters for ResolveDates.
+type ResolveDatesParams struct {
+	// Expression The dates of the stay as the customer told them.
+	Expression string `form:"expression" json:"expression"`
+
+	// ReferenceDate The date the expression is relative to, today in the time zone by default.
+	ReferenceDate *openapi_types.Date `form:"referenceDate,omitempty" json:"referenceDate,omitempty"`
+
+	// TimeZone IANA time zone today is taken in, UTC by default.
+	TimeZone *string `form:"timeZone,omitempty" json:"timeZone,omitempty"`
+
+	// PropertyId Property in whose time zone today is taken, unless the timeZone is given.
+	PropertyId *int `form:"propertyId,omitempty" json:"propertyId,omitempty"`
+}
+
+// SearchPropertiesParams defines parameters for SearchProperties.
+type SearchPropertiesParams struct {
+	// Format Set to agent for a compact summary with a sentence per result, in the application/vnd.booking.agent+json media type, instead of the full resources.
+	Format *SearchPropertiesParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// SearchPropertiesParamsFormat defines parameters for SearchProperties.
+type SearchPropertiesParamsFormat string
+
+// GetPropertyParams defines parameters for GetProperty.
+type GetPropertyParams struct {
+	// Format Set to agent for a compact summary with a sentence per result, in the application/vnd.booking.agent+json media type, instead of the full resources.
+	Format *GetPropertyParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetPropertyParamsFormat defines parameters for GetProperty.
+type GetPropertyParamsFormat string
+
+// GetAvailabilityParams defines parameters for GetAvailability.
+type GetAvailabilityParams struct {
+	// StartDate The date since which the stay will start.
 	StartDate openapi_types.Date `form:"startDate" json:"startDate"`
-	EndDate   openapi_types.Date `form:"endDate" json:"endDate"`
+
+	// EndDate The date till which the stay will last.
+	EndDate openapi_types.Date `form:"endDate" json:"endDate"`
+
+	// Adults Adults staying, 1 by default.
+	Adults *int `form:"adults,omitempty" json:"adults,omitempty"`
+
+	// Children Children staying, 0 by default.
+	Children *int `form:"children,omitempty" json:"children,omitempty"`
+
+	// Pets Pets staying, 0 by default.
+	Pets *int `form:"pets,omitempty" json:"pets,omitempty"`
+
+	// RoomTypeId Room type to check, all the room types of the property by default.
+	RoomTypeId *string `form:"roomTypeId,omitempty" json:"roomTypeId,omitempty"`
+
+	// RatePlanId Rate plan to price the stay with, the standard rate by default.
+	RatePlanId *string `form:"ratePlanId,omitempty" json:"ratePlanId,omitempty"`
+
+	// PromoCode Promo code whose discount is taken off the prices.
+	PromoCode *string `form:"promoCode,omitempty" json:"promoCode,omitempty"`
+
+	// Format Set to agent for a compact summary with a sentence per result, in the application/vnd.booking.agent+json media type, instead of the full resources.
+	Format *GetAvailabilityParamsFormat `form:"format,omitempty" json:"format,omitempty"`
 }
 
-// PostBookingsJSONRequestBody defines body for PostBookings for application/json ContentType.
-type PostBookingsJSONRequestBody = BookingRequest
+// GetAvailabilityParamsFormat defines parameters for GetAvailability.
+type GetAvailabilityParamsFormat string
+
+// CreatePromotionJSONRequestBody defines body for CreatePromotion for application/json ContentType.
+type CreatePromotionJSONRequestBody = PromotionRequest
+
+// CreateBlockJSONRequestBody defines body for CreateBlock for application/json ContentType.
+type CreateBlockJSONRequestBody = BlockRequest
+
+// UpdateBookingDraftJSONRequestBody defines body for UpdateBookingDraft for application/json ContentType.
+type UpdateBookingDraftJSONRequestBody = BookingDraftFields
+
+// SubmitBookingDraftJSONRequestBody defines body for SubmitBookingDraft for application/json ContentType.
+type SubmitBookingDraftJSONRequestBody = BookingDraftSubmission
+
+// BookPropertyJSONRequestBody defines body for BookProperty for application/json ContentType.
+type BookPropertyJSONRequestBody = BookingRequest
 
-// PostPropertiesSearchJSONRequestBody defines body for PostPropertiesSearch for application/json ContentType.
-type PostPropertiesSearchJSONRequestBody = SearchOptions
+// SearchPropertiesJSONRequestBody defines body for SearchProperties for application/json ContentType.
+type SearchPropertiesJSONRequestBody = SearchOptions
@@ -1,8 +1,39 @@
 package domain
 
 //go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml ../../api.yaml
+//go:generate go run github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen --config=server.config.yaml ../../api.yaml
 
+import (
+	"time"
+	// the Lambda runtime has no time zone database
+	_ "time/tzdata"
+)
+
+// Booking is a booking as it is stored. Unit is the unit of the room type
+// allocated to it, nil for a property booked as a whole. Cancellation keeps
+// the terms of the rate plan as they were when it was booked, nil for the
+// standard rate, which may be cancelled at any time.
 type Booking struct {
 	BookingRequest
-	BookingId string `json:"bookingId"`
+	BookingId    string             `json:"bookingId"`
+	Unit         *int               `json:"unit,omitempty"`
+	Cancellation *CancellationTerms `json:"cancellation,omitempty"`
+}
+
+// Draft is a booking draft as it is stored: the fields collected in an agent
+// session so far, kept until it expires. Version counts the puts of the
+// draft, so that a put of a draft read before another one fails.
+type Draft struct {
+	SessionId string             `json:"sessionId"`
+	Fields    BookingDraftFields `json:"fields"`
+	ExpiresAt time.Time          `json:"expiresAt"`
+	Version   int                `json:"version"`
+}
+
+// LoadLocation loads the time zone of the property, UTC if it has none.
+func (property Property) LoadLocation() (*time.Location, error) {
+	if property.TimeZone == nil || *property.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(*property.TimeZone)
 }
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Error string
 
 func (err Error) Error() string {
@@ -7,7 +12,65 @@ func (err Error) Error() string {
 }
 
 const (
-	ErrPropertyNotFound     = Error("property not found")
-	ErrBookingNotFound      = Error("booking not found")
-	ErrPropertyNotAvailable = Error("property not available")
+	ErrPropertyNotFound      = Error("property not found")
+	ErrBookingNotFound       = Error("booking not found")
+	ErrBlockNotFound         = Error("block not found")
+	ErrRoomTypeNotFound      = Error("room type not found")
+	ErrRatePlanNotFound      = Error("rate plan not found")
+	ErrPromotionNotFound     = Error("promotion not found")
+	ErrPromotionExists       = Error("promotion already exists")
+	ErrInvalidPromoCode      = Error("promo code does not apply")
+	ErrPropertyNotAvailable  = Error("property not available")
+	ErrNotCancellable        = Error("booking can no longer be cancelled")
+	ErrInvalidRequest        = Error("invalid request")
+	ErrInvalidDateRange      = Error("end date should be after start date")
+	ErrStayRules             = Error("stay breaks the rules of the property")
+	ErrCapacityExceeded      = Error("too many guests for the property")
+	ErrPetsNotAllowed        = Error("pets not allowed at the property")
+	ErrMissingSearchLocation = Error("missing city or country")
+	ErrDraftNotFound         = Error("booking draft not found")
+	ErrDraftIncomplete       = Error("booking draft has missing or invalid fields")
+	ErrUnresolvedDates       = Error("unable to resolve the dates")
 )
+
+// ValidationError reports the fields of a request that are not valid. It
+// wraps the error describing the problem as a whole, so that it still
+// matches it with errors.Is.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func NewValidationError(err error, fields ...FieldError) *ValidationError {
+	return &ValidationError{Err: err, Fields: fields}
+}
+
+func (err *ValidationError) Error() string {
+	messages := make([]string, len(err.Fields))
+	for i, field := range err.Fields {
+		messages[i] = fmt.Sprintf("%s: %s", field.Field, field.Message)
+	}
+	return fmt.Sprintf("%s (%s)", err.Err, strings.Join(messages, "; "))
+}
+
+func (err *ValidationError) Unwrap() error {
+	return err.Err
+}
+
+// StayRulesError reports the stay rules of the property a stay breaks. It
+// matches ErrStayRules with errors.Is.
+type StayRulesError struct {
+	Violations []RuleViolation
+}
+
+func (err *StayRulesError) Error() string {
+	messages := make([]string, len(err.Violations))
+	for i, violation := range err.Violations {
+		messages[i] = fmt.Sprintf("%s: %s", violation.Rule, violation.Message)
+	}
+	return fmt.Sprintf("%s (%s)", ErrStayRules, strings.Join(messages, "; "))
+}
+
+func (err *StayRulesError) Unwrap() error {
+	return ErrStayRules
+}
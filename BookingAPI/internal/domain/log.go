@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"booking/internal/logging"
+	"log/slog"
+)
+
+// LogValue logs the request with the contact and payment details masked.
+func (request BookingRequest) LogValue() slog.Value {
+	return slog.GroupValue(request.logAttrs()...)
+}
+
+// LogValue logs the booking with the contact and payment details masked.
+func (booking Booking) LogValue() slog.Value {
+	return slog.GroupValue(append([]slog.Attr{slog.String("bookingId", booking.BookingId)},
+		booking.BookingRequest.logAttrs()...)...)
+}
+
+func (request BookingRequest) logAttrs() []slog.Attr {
+	return []slog.Attr{
+		slog.Int("propertyId", request.PropertyId),
+		slog.String("startDate", request.StartDate.String()),
+		slog.String("endDate", request.EndDate.String()),
+		slog.Group("contactDetails",
+			maskedAttr("email", request.ContactDetails.Email, logging.MaskEmail),
+			maskedAttr("phone", request.ContactDetails.Phone, logging.MaskPhone),
+		),
+		slog.Group("paymentInformation",
+			maskedAttr("cardNumber", request.PaymentInformation.CardNumber, logging.MaskCardNumber),
+			maskedAttr("cvv", request.PaymentInformation.Cvv, nil),
+			maskedAttr("expiryDate", request.PaymentInformation.ExpiryDate, nil),
+		),
+	}
+}
+
+// maskedAttr logs whether the value is set, masked with mask or hidden
+// altogether when mask is nil.
+func maskedAttr(key string, value *string, mask func(string) string) slog.Attr {
+	switch {
+	case value == nil:
+		return slog.Any(key, nil)
+	case mask == nil:
+		return slog.String(key, "***")
+	}
+	return slog.String(key, mask(*value))
+}
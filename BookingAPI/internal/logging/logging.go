@@ -0,0 +1,69 @@
+// Package logging provides the JSON logger of the functions. Every request
+// carries its own logger in the context, with the attributes correlating
+// the lines of the request, and personal data is masked before it is written.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+type contextKey struct{}
+
+// holder lets the code serving a request add attributes seen by the
+// middlewares that log after it returns.
+type holder struct {
+	mutex  sync.Mutex
+	logger *slog.Logger
+}
+
+// New creates a JSON logger writing the records of the level, one of
+// debug, info, warn and error, or above to w.
+func New(w io.Writer, level string) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       parseLevel(level),
+		ReplaceAttr: redact,
+	}))
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+// NewContext returns a copy of ctx carrying the logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, &holder{logger: logger})
+}
+
+// FromContext returns the logger of the context, the default one if the
+// context carries none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if h, ok := ctx.Value(contextKey{}).(*holder); ok {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		return h.logger
+	}
+	return slog.Default()
+}
+
+// Add adds the attributes to the logger of the context, e.g. the ID of the
+// property once the request is decoded. Unlike slog.Logger.With it affects
+// everything logged afterwards with the context, also by the callers.
+func Add(ctx context.Context, args ...any) {
+	if h, ok := ctx.Value(contextKey{}).(*holder); ok {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		h.logger = h.logger.With(args...)
+	}
+}
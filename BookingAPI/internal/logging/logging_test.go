@@ -0,0 +1,50 @@
+package logging_test
+
+import (
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRedactsBookingRequest(t *testing.T) {
+	var out bytes.Buffer
+	logger := logging.New(&out, "info")
+
+	email, phone, card, cvv := "john.doe@example.com", "+48 600 100 200", "4111 1111 1111 1234", "123"
+	var request domain.BookingRequest
+	request.ContactDetails.Email = &email
+	request.ContactDetails.Phone = &phone
+	request.PaymentInformation.CardNumber = &card
+	request.PaymentInformation.Cvv = &cvv
+
+	logger.Info("booking", "request", request, "email", email)
+
+	line := out.String()
+	for _, secret := range []string{"john.doe", "600 100", "4111", `"123"`} {
+		if strings.Contains(line, secret) {
+			t.Errorf("logged %s, want %q masked", line, secret)
+		}
+	}
+	for _, kept := range []string{"j***@example.com", "************1234"} {
+		if !strings.Contains(line, kept) {
+			t.Errorf("logged %s, want %q", line, kept)
+		}
+	}
+}
+
+func TestAddAffectsCallers(t *testing.T) {
+	var out bytes.Buffer
+	ctx := logging.NewContext(context.Background(), logging.New(&out, "info").With("request_id", "r1"))
+
+	func(ctx context.Context) {
+		logging.Add(ctx, "property_id", 7)
+	}(ctx)
+	logging.FromContext(ctx).Info("request served")
+
+	if line := out.String(); !strings.Contains(line, `"request_id":"r1"`) || !strings.Contains(line, `"property_id":7`) {
+		t.Errorf("logged %s, want the request and property IDs", line)
+	}
+}
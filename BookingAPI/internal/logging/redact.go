@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// masks maps the keys of the personal data to the functions masking them.
+// The keys are the JSON names of the fields of domain.BookingRequest.
+var masks = map[string]func(string) string{
+	"email":      MaskEmail,
+	"phone":      MaskPhone,
+	"cardNumber": MaskCardNumber,
+	"cvv":        mask,
+	"expiryDate": mask,
+}
+
+// redact masks the attributes holding personal data, in any group.
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	maskValue, ok := masks[attr.Key]
+	if !ok {
+		return attr
+	}
+
+	switch value := attr.Value.Any().(type) {
+	case nil:
+		return attr
+	case string:
+		return slog.String(attr.Key, maskValue(value))
+	case *string:
+		if value != nil {
+			return slog.String(attr.Key, maskValue(*value))
+		}
+		return attr
+	}
+	return slog.String(attr.Key, mask(""))
+}
+
+// MaskEmail keeps the first letter of the local part and the domain,
+// j***@example.com.
+func MaskEmail(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found || local == "" {
+		return mask(email)
+	}
+	return local[:1] + "***@" + domain
+}
+
+// MaskPhone keeps the last two digits.
+func MaskPhone(phone string) string {
+	return keepLast(phone, 2)
+}
+
+// MaskCardNumber keeps the last four digits, as printed on receipts.
+func MaskCardNumber(number string) string {
+	return keepLast(strings.ReplaceAll(number, " ", ""), 4)
+}
+
+func keepLast(value string, n int) string {
+	if len(value) <= n*2 {
+		return mask(value)
+	}
+	return strings.Repeat("*", len(value)-n) + value[len(value)-n:]
+}
+
+func mask(string) string {
+	return "***"
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// emfRecorder writes every value as a line in the CloudWatch Embedded
+// Metric Format. The lines written to the output of a Lambda function are
+// turned into metrics by CloudWatch Logs, see
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch_Embedded_Metric_Format_Specification.html
+type emfRecorder struct {
+	mutex     sync.Mutex
+	encoder   *json.Encoder
+	namespace string
+	now       func() time.Time
+}
+
+// NewEMF creates a recorder writing the metrics of the namespace to w.
+func NewEMF(w io.Writer, namespace string) *emfRecorder {
+	return &emfRecorder{
+		encoder:   json.NewEncoder(w),
+		namespace: namespace,
+		now:       time.Now,
+	}
+}
+
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+type emfDirective struct {
+	Namespace  string      `json:"Namespace"`
+	Dimensions [][]string  `json:"Dimensions"`
+	Metrics    []emfMetric `json:"Metrics"`
+}
+
+type emfMetric struct {
+	Name string `json:"Name"`
+	Unit Unit   `json:"Unit"`
+}
+
+func (recorder *emfRecorder) Record(ctx context.Context, name string, value float64, unit Unit,
+	dimensions ...Dimension) {
+
+	names := make([]string, len(dimensions))
+	line := map[string]any{}
+	for i, dimension := range dimensions {
+		names[i] = dimension.Name
+		line[dimension.Name] = dimension.Value
+	}
+	line[name] = value
+
+	// not a dimension, lets the line be found from the trace
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		line["traceId"] = spanContext.TraceID().String()
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	line["_aws"] = emfMetadata{
+		Timestamp: recorder.now().UnixMilli(),
+		CloudWatchMetrics: []emfDirective{{
+			Namespace:  recorder.namespace,
+			Dimensions: [][]string{names},
+			Metrics:    []emfMetric{{Name: name, Unit: unit}},
+		}},
+	}
+	// the metrics must not fail the request, a lost line is a lost value
+	recorder.encoder.Encode(line)
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEMFRecord(t *testing.T) {
+	var out bytes.Buffer
+	recorder := NewEMF(&out, "BookingAPI")
+	recorder.now = func() time.Time { return time.UnixMilli(1700000000000) }
+
+	recorder.Record(context.Background(), BookingsCreated, 1, UnitCount, Dimension{Name: "City", Value: "Krakow"})
+
+	var line map[string]any
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+		t.Fatalf("Record() wrote %q, want a JSON line: %v", out.String(), err)
+	}
+	if line["City"] != "Krakow" || line[BookingsCreated] != 1.0 {
+		t.Errorf("Record() wrote %s, want the dimension and the value", out.String())
+	}
+
+	var metadata struct {
+		AWS emfMetadata `json:"_aws"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &metadata); err != nil {
+		t.Fatal(err)
+	}
+	want := emfMetadata{
+		Timestamp: 1700000000000,
+		CloudWatchMetrics: []emfDirective{{
+			Namespace:  "BookingAPI",
+			Dimensions: [][]string{{"City"}},
+			Metrics:    []emfMetric{{Name: BookingsCreated, Unit: UnitCount}},
+		}},
+	}
+	if !reflect.DeepEqual(metadata.AWS, want) {
+		t.Errorf("Record() metadata = %+v, want %+v", metadata.AWS, want)
+	}
+}
+
+func TestMemorySum(t *testing.T) {
+	memory := NewMemory()
+	krakow := Dimension{Name: "City", Value: "Krakow"}
+	ctx := context.Background()
+
+	memory.Record(ctx, Searches, 1, UnitCount, krakow)
+	memory.Record(ctx, Searches, 1, UnitCount, Dimension{Name: "City", Value: "Gdansk"})
+	memory.Record(ctx, BookingValue, 110, UnitNone, krakow)
+
+	if sum := memory.Sum(Searches); sum != 2 {
+		t.Errorf("Sum(Searches) = %v, want 2", sum)
+	}
+	if sum := memory.Sum(Searches, krakow); sum != 1 {
+		t.Errorf("Sum(Searches, krakow) = %v, want 1", sum)
+	}
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Value is a value recorded by the Memory recorder.
+type Value struct {
+	Name       string
+	Value      float64
+	Unit       Unit
+	Dimensions []Dimension
+}
+
+// Memory keeps the recorded values, for the tests to check them.
+type Memory struct {
+	mutex  sync.Mutex
+	values []Value
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (memory *Memory) Record(ctx context.Context, name string, value float64, unit Unit,
+	dimensions ...Dimension) {
+
+	memory.mutex.Lock()
+	defer memory.mutex.Unlock()
+	memory.values = append(memory.values, Value{Name: name, Value: value, Unit: unit, Dimensions: dimensions})
+}
+
+// Values returns the values recorded so far.
+func (memory *Memory) Values() []Value {
+	memory.mutex.Lock()
+	defer memory.mutex.Unlock()
+	return append([]Value(nil), memory.values...)
+}
+
+// Sum adds up the values of the metric recorded with all the dimensions,
+// and possibly more.
+func (memory *Memory) Sum(name string, dimensions ...Dimension) float64 {
+	sum := 0.0
+	for _, value := range memory.Values() {
+		if value.Name == name && hasDimensions(value, dimensions) {
+			sum += value.Value
+		}
+	}
+	return sum
+}
+
+func hasDimensions(value Value, dimensions []Dimension) bool {
+	for _, dimension := range dimensions {
+		if !slices.Contains(value.Dimensions, dimension) {
+			return false
+		}
+	}
+	return true
+}
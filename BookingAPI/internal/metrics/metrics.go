@@ -0,0 +1,97 @@
+// Package metrics records the business metrics of the API, e.g. the
+// bookings created or the searches per city. The metrics go to the default
+// recorder, a no-op one until SetDefault is called, so the code recording
+// them does not depend on where they end up.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// The names of the metrics.
+const (
+	Searches           = "Searches"
+	AvailabilityChecks = "AvailabilityChecks"
+	AvailabilityHits   = "AvailabilityHits"
+	BookingsCreated    = "BookingsCreated"
+	BookingsCancelled  = "BookingsCancelled"
+	BookingConflicts   = "BookingConflicts"
+	BookingValue       = "BookingValue"
+	DynamoDBLatency    = "DynamoDBLatency"
+)
+
+// Unit is a unit of the CloudWatch metrics.
+type Unit string
+
+const (
+	UnitCount        Unit = "Count"
+	UnitMilliseconds Unit = "Milliseconds"
+	UnitNone         Unit = "None"
+)
+
+// Dimension qualifies a metric, e.g. the city the searches are made in.
+type Dimension struct {
+	Name  string
+	Value string
+}
+
+// Recorder records the values of the metrics.
+type Recorder interface {
+	Record(ctx context.Context, name string, value float64, unit Unit, dimensions ...Dimension)
+}
+
+type nopRecorder struct{}
+
+func (nopRecorder) Record(context.Context, string, float64, Unit, ...Dimension) {}
+
+// Nop discards the metrics.
+var Nop Recorder = nopRecorder{}
+
+var defaultRecorder atomic.Value
+
+func init() {
+	SetDefault(Nop)
+}
+
+// SetDefault makes the recorder the one the metrics are recorded with.
+func SetDefault(recorder Recorder) {
+	defaultRecorder.Store(&recorder)
+}
+
+// Default returns the recorder the metrics are recorded with.
+func Default() Recorder {
+	return *defaultRecorder.Load().(*Recorder)
+}
+
+// Record records the value with the default recorder.
+func Record(ctx context.Context, name string, value float64, unit Unit, dimensions ...Dimension) {
+	Default().Record(ctx, name, value, unit, dimensions...)
+}
+
+// Count records one occurrence of the event with the default recorder.
+func Count(ctx context.Context, name string, dimensions ...Dimension) {
+	Record(ctx, name, 1, UnitCount, dimensions...)
+}
+
+// The exporters Setup accepts.
+const (
+	ExporterNone = "none"
+	ExporterEMF  = "emf"
+)
+
+// Setup makes the metrics of the namespace go to the exporter, with
+// ExporterEMF as lines of the standard output.
+func Setup(exporter, namespace string) error {
+	switch exporter {
+	case ExporterNone:
+		SetDefault(Nop)
+	case ExporterEMF:
+		SetDefault(NewEMF(os.Stdout, namespace))
+	default:
+		return fmt.Errorf("unknown metrics exporter %q", exporter)
+	}
+	return nil
+}
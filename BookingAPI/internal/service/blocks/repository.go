@@ -0,0 +1,18 @@
+package blocks
+
+import (
+	"booking/internal/domain"
+	"context"
+)
+
+// The repositories report missing items with database.ErrNotFound.
+
+type blocksRepository interface {
+	AddBlock(ctx context.Context, block domain.Block) error
+	GetBlocksForProperty(ctx context.Context, propertyId int) ([]domain.Block, error)
+	RemoveBlock(ctx context.Context, blockId string) error
+}
+
+type propertiesRepository interface {
+	GetProperty(ctx context.Context, propertyId int) (*domain.Property, error)
+}
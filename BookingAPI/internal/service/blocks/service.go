@@ -0,0 +1,119 @@
+// Package blocks takes the dates of the properties offline, e.g. for
+// maintenance, renovation or personal use, so that they cannot be booked.
+package blocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"booking/internal/database"
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"booking/internal/tracing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxNights keeps a block within the nights the stores take at once, the
+// longer closures are blocked in parts.
+const maxNights = 90
+
+type blocksService struct {
+	blocksRepository     blocksRepository
+	propertiesRepository propertiesRepository
+}
+
+func NewService(blocksRepository blocksRepository, propertiesRepository propertiesRepository) *blocksService {
+	return &blocksService{
+		blocksRepository:     blocksRepository,
+		propertiesRepository: propertiesRepository,
+	}
+}
+
+// Add blocks the dates of the property. The nights booked, for the property
+// as a whole or any of its units, or blocked already fail it with
+// domain.ErrPropertyNotAvailable.
+func (srv *blocksService) Add(ctx context.Context, propertyId int, request domain.BlockRequest) (
+	_ domain.Block, err error) {
+
+	ctx, span := tracing.Start(ctx, "blocksService.Add", attribute.Int("property.id", propertyId))
+	defer tracing.End(span, &err)
+
+	if !request.EndDate.After(request.StartDate.Time) {
+		return domain.Block{}, domain.NewValidationError(domain.ErrInvalidDateRange,
+			domain.FieldError{Field: "endDate", Message: "End date should be after start date."})
+	}
+	if nights := len(database.NightsBetween(request.StartDate.Time, request.EndDate.Time)); nights > maxNights {
+		return domain.Block{}, domain.NewValidationError(domain.ErrInvalidRequest, domain.FieldError{
+			Field:   "endDate",
+			Message: fmt.Sprintf("A block spans at most %d nights, longer ones are added in parts.", maxNights),
+		})
+	}
+	if err := srv.checkProperty(ctx, propertyId); err != nil {
+		return domain.Block{}, err
+	}
+
+	block := domain.Block{
+		BlockId:    uuid.New(),
+		PropertyId: propertyId,
+		StartDate:  request.StartDate,
+		EndDate:    request.EndDate,
+		Reason:     request.Reason,
+	}
+	span.SetAttributes(attribute.String("block.id", block.BlockId.String()))
+	err = srv.blocksRepository.AddBlock(ctx, block)
+	if errors.Is(err, database.ErrOverlap) {
+		return domain.Block{}, domain.ErrPropertyNotAvailable
+	} else if err != nil {
+		return domain.Block{}, err
+	}
+	logging.FromContext(ctx).Info("block created", "block", block)
+	return block, nil
+}
+
+// List returns the blocks of the property by their start date.
+func (srv *blocksService) List(ctx context.Context, propertyId int) (_ []domain.Block, err error) {
+	ctx, span := tracing.Start(ctx, "blocksService.List", attribute.Int("property.id", propertyId))
+	defer tracing.End(span, &err)
+
+	if err := srv.checkProperty(ctx, propertyId); err != nil {
+		return nil, err
+	}
+	blocks, err := srv.blocksRepository.GetBlocksForProperty(ctx, propertyId)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(blocks, func(a, b domain.Block) int {
+		return a.StartDate.Compare(b.StartDate.Time)
+	})
+	if blocks == nil {
+		blocks = []domain.Block{}
+	}
+	return blocks, nil
+}
+
+// Remove makes the dates of the block available again.
+func (srv *blocksService) Remove(ctx context.Context, blockId uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "blocksService.Remove", attribute.String("block.id", blockId.String()))
+	defer tracing.End(span, &err)
+
+	err = srv.blocksRepository.RemoveBlock(ctx, blockId.String())
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.ErrBlockNotFound
+	} else if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("block removed")
+	return nil
+}
+
+func (srv *blocksService) checkProperty(ctx context.Context, propertyId int) error {
+	_, err := srv.propertiesRepository.GetProperty(ctx, propertyId)
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.ErrPropertyNotFound
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package blocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func TestAddBlock(t *testing.T) {
+	ctx := context.Background()
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, memory.NewPropertiesStore(domain.Property{PropertyId: 1, Guests: 4}))
+	date := func(day int) openapi_types.Date {
+		return openapi_types.Date{Time: time.Date(2024, time.November, day, 0, 0, 0, 0, time.UTC)}
+	}
+	email := "john.doe@example.com"
+	err := bookingsStore.AddBooking(ctx, domain.Booking{
+		BookingId: uuid.NewString(),
+		BookingRequest: domain.BookingRequest{PropertyId: 1, CustomerName: "John Doe",
+			StartDate: date(20), EndDate: date(22), ContactDetails: domain.ContactDetails{Email: &email}},
+	})
+	if err != nil {
+		t.Fatalf("AddBooking() error = %v", err)
+	}
+	roomTypeId, unit := "double", 1
+	err = bookingsStore.AddBooking(ctx, domain.Booking{
+		BookingId: uuid.NewString(),
+		BookingRequest: domain.BookingRequest{PropertyId: 1, CustomerName: "Jane Doe",
+			StartDate: date(24), EndDate: date(26), RoomTypeId: &roomTypeId,
+			ContactDetails: domain.ContactDetails{Email: &email}},
+		Unit: &unit,
+	})
+	if err != nil {
+		t.Fatalf("AddBooking() error = %v", err)
+	}
+
+	block, err := srv.Add(ctx, 1, domain.BlockRequest{StartDate: date(4), EndDate: date(18), Reason: "Renovation"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if block.PropertyId != 1 || block.Reason != "Renovation" || block.BlockId == uuid.Nil {
+		t.Errorf("Add() = %+v", block)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		propertyId int
+		start, end int
+		want       error
+	}{
+		{"booked nights", 1, 18, 21, domain.ErrPropertyNotAvailable},
+		{"blocked nights", 1, 1, 5, domain.ErrPropertyNotAvailable},
+		{"booked unit", 1, 25, 28, domain.ErrPropertyNotAvailable},
+		{"reversed dates", 1, 28, 25, domain.ErrInvalidDateRange},
+		{"unknown property", 2, 4, 18, domain.ErrPropertyNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := srv.Add(ctx, tc.propertyId,
+				domain.BlockRequest{StartDate: date(tc.start), EndDate: date(tc.end), Reason: "Maintenance"})
+			if !errors.Is(err, tc.want) {
+				t.Errorf("Add() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	blocks, err := srv.List(ctx, 1)
+	if err != nil || len(blocks) != 1 || blocks[0].BlockId != block.BlockId {
+		t.Errorf("List() = %+v, %v, want the block added", blocks, err)
+	}
+}
+
+func TestRemoveBlock(t *testing.T) {
+	ctx := context.Background()
+	srv := NewService(memory.NewBookingsStore(), memory.NewPropertiesStore(domain.Property{PropertyId: 1, Guests: 4}))
+	start := openapi_types.Date{Time: time.Date(2024, time.November, 4, 0, 0, 0, 0, time.UTC)}
+	block, err := srv.Add(ctx, 1, domain.BlockRequest{StartDate: start,
+		EndDate: openapi_types.Date{Time: start.AddDate(0, 0, 7)}, Reason: "Personal use"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := srv.Remove(ctx, block.BlockId); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := srv.Remove(ctx, block.BlockId); !errors.Is(err, domain.ErrBlockNotFound) {
+		t.Errorf("Remove() error = %v, want %v", err, domain.ErrBlockNotFound)
+	}
+	if blocks, err := srv.List(ctx, 1); err != nil || len(blocks) != 0 {
+		t.Errorf("List() = %+v, %v, want no blocks", blocks, err)
+	}
+}
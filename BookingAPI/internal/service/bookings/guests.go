@@ -0,0 +1,71 @@
+package bookings
+
+import (
+	"fmt"
+
+	"booking/internal/domain"
+)
+
+// party is the guests of a stay, the counts not given taking their defaults.
+type party struct {
+	adults, children, infants, pets int
+}
+
+func newParty(guests *domain.Guests) party {
+	p := party{adults: 1}
+	if guests != nil {
+		set(&p.adults, guests.Adults)
+		set(&p.children, guests.Children)
+		set(&p.infants, guests.Infants)
+		set(&p.pets, guests.Pets)
+	}
+	return p
+}
+
+// guests counts the guests against the capacity, the infants aside.
+func (p party) guests() int {
+	return p.adults + p.children
+}
+
+func (p party) toGuests() *domain.Guests {
+	return &domain.Guests{Adults: &p.adults, Children: &p.children, Infants: &p.infants, Pets: &p.pets}
+}
+
+// check tells whether the property hosts the party, failing with
+// domain.ErrCapacityExceeded or domain.ErrPetsNotAllowed.
+func (p party) check(property domain.Property) error {
+	if p.guests() > property.Guests {
+		return domain.NewValidationError(domain.ErrCapacityExceeded, domain.FieldError{
+			Field:   "guests",
+			Message: fmt.Sprintf("The property hosts up to %s, infants aside.", plural(property.Guests, "guest", "guests")),
+		})
+	}
+
+	maxPets := 0
+	set(&maxPets, property.MaxPets)
+	if p.pets > maxPets {
+		message := "The property allows no pets."
+		if maxPets > 0 {
+			message = fmt.Sprintf("The property allows up to %s.", plural(maxPets, "pet", "pets"))
+		}
+		return domain.NewValidationError(domain.ErrPetsNotAllowed,
+			domain.FieldError{Field: "guests.pets", Message: message})
+	}
+	return nil
+}
+
+// fees returns the fees per night for the guests beyond the ones the base
+// price covers and for the pets.
+func (p party) fees(property domain.Property) float32 {
+	included := property.Guests
+	set(&included, property.IncludedGuests)
+	var extraGuestFee, petFee float32
+	set(&extraGuestFee, property.ExtraGuestFee)
+	set(&petFee, property.PetFee)
+
+	fees := float32(p.pets) * petFee
+	if extra := p.guests() - included; extra > 0 {
+		fees += float32(extra) * extraGuestFee
+	}
+	return fees
+}
@@ -0,0 +1,218 @@
+package bookings
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"booking/internal/database"
+	"booking/internal/domain"
+)
+
+// The dynamic rates follow the demand for the property: the recent occupancy
+// of the property and of its city, how close the night is and its weekday.
+const (
+	// occupancyWindow is how many nights up to today the recent occupancy
+	// is measured over.
+	occupancyWindow = 28
+	// targetOccupancy keeps the base rate, every point of occupancy above or
+	// below it moves the rate by occupancyWeight of a point.
+	targetOccupancy = 0.6
+	occupancyWeight = 0.5
+	// the nights within lastMinuteDays of today are discounted to fill them
+	lastMinuteDays   = 7
+	lastMinuteFactor = 0.9
+	// Friday and Saturday nights
+	weekendFactor = 1.15
+	// occupancyTTL is how long a measured occupancy is reused, sparing the
+	// reads of the bookings of the whole city on every price.
+	occupancyTTL = 15 * time.Minute
+	// maxNeighbours bounds the properties of a city its occupancy is measured
+	// over.
+	maxNeighbours = 50
+)
+
+// nightlyRates tells the rate of every night of a stay at a property, as a
+// factor of its base rate, 1 for a property with static rates.
+type nightlyRates struct {
+	pricing *domain.DynamicPricing
+	today   time.Time
+	// occupancy is the recent occupancy of the property and of its city,
+	// averaged, from 0 to 1
+	occupancy float64
+}
+
+// factor returns the factor of the base rate of the night, within the floor
+// and the ceiling of the dynamic pricing.
+func (r nightlyRates) factor(night time.Time) float64 {
+	if r.pricing == nil {
+		return 1
+	}
+	factor := 1 + occupancyWeight*(r.occupancy-targetOccupancy)
+	if night.Before(r.today.AddDate(0, 0, lastMinuteDays)) {
+		factor *= lastMinuteFactor
+	}
+	if weekday := night.Weekday(); weekday == time.Friday || weekday == time.Saturday {
+		factor *= weekendFactor
+	}
+	floor, ceiling := float64(r.pricing.FloorPercent)/100, float64(r.pricing.CeilingPercent)/100
+	return math.Max(floor, math.Min(ceiling, factor))
+}
+
+// nightlyRates returns the rates of the nights at the property booked now,
+// measuring the recent occupancy of the property and of the properties in its
+// city for the ones with dynamic pricing.
+func (srv *bookingsService) nightlyRates(ctx context.Context, property domain.Property, schedule schedule) (
+	nightlyRates, error) {
+
+	rates := nightlyRates{pricing: property.DynamicPricing, today: schedule.today(srv.now())}
+	if rates.pricing == nil {
+		return rates, nil
+	}
+
+	propertyNights, err := srv.recentNights(ctx, occupancyKey{propertyId: property.PropertyId, today: rates.today},
+		func() ([]domain.Property, error) { return []domain.Property{property}, nil })
+	if err != nil {
+		return nightlyRates{}, err
+	}
+	cityNights := propertyNights
+	if property.City != "" {
+		cityNights, err = srv.recentNights(ctx, occupancyKey{city: property.City, today: rates.today},
+			func() ([]domain.Property, error) {
+				neighbours, err := srv.propertiesRepository.Search(ctx, domain.SearchOptions{City: &property.City})
+				return neighbours[:min(len(neighbours), maxNeighbours)], err
+			})
+		if err != nil {
+			return nightlyRates{}, err
+		}
+	}
+
+	rates.occupancy = (propertyNights.occupancy() + cityNights.occupancy()) / 2
+	return rates, nil
+}
+
+// recentNights returns the nights of the properties booked over the
+// occupancy window up to today, the ones measured within occupancyTTL
+// reused.
+func (srv *bookingsService) recentNights(ctx context.Context, key occupancyKey,
+	properties func() ([]domain.Property, error)) (measuredNights, error) {
+
+	if nights, ok := srv.recentOccupancy.get(key, srv.now()); ok {
+		return nights, nil
+	}
+	measured, err := properties()
+	if err != nil {
+		return measuredNights{}, err
+	}
+	nights := measuredNights{measuredAt: srv.now()}
+	from := key.today.AddDate(0, 0, -occupancyWindow)
+	for _, property := range measured {
+		booked, capacity, err := srv.bookedNights(ctx, property, from, key.today)
+		if err != nil {
+			return measuredNights{}, err
+		}
+		nights.booked += booked
+		nights.capacity += capacity
+	}
+	srv.recentOccupancy.put(key, nights)
+	return nights, nil
+}
+
+// occupancyCache keeps the recent nights measured of the properties and of
+// the cities for occupancyTTL, as the functions serve many prices in a row.
+type occupancyCache struct {
+	mutex   sync.Mutex
+	entries map[occupancyKey]measuredNights
+}
+
+// occupancyKey is either a property or a city, on the day it is measured.
+type occupancyKey struct {
+	propertyId int
+	city       string
+	today      time.Time
+}
+
+type measuredNights struct {
+	booked, capacity int
+	measuredAt       time.Time
+}
+
+// occupancy returns the share of the nights booked, none without any nights.
+func (nights measuredNights) occupancy() float64 {
+	if nights.capacity == 0 {
+		return 0
+	}
+	return float64(nights.booked) / float64(nights.capacity)
+}
+
+func newOccupancyCache() *occupancyCache {
+	return &occupancyCache{entries: map[occupancyKey]measuredNights{}}
+}
+
+func (cache *occupancyCache) get(key occupancyKey, now time.Time) (measuredNights, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	nights, ok := cache.entries[key]
+	if !ok || now.Sub(nights.measuredAt) >= occupancyTTL {
+		return measuredNights{}, false
+	}
+	return nights, true
+}
+
+// put keeps the nights measured, removing the expired ones.
+func (cache *occupancyCache) put(key occupancyKey, nights measuredNights) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for cached, entry := range cache.entries {
+		if nights.measuredAt.Sub(entry.measuredAt) >= occupancyTTL {
+			delete(cache.entries, cached)
+		}
+	}
+	cache.entries[key] = nights
+}
+
+// bookedNights counts the nights of the units of the property booked from
+// the start date up to the end date, out of all of them, the whole property
+// counting as a single unit. A booking without a unit takes all the units.
+func (srv *bookingsService) bookedNights(ctx context.Context, property domain.Property, startDate, endDate time.Time) (
+	booked, capacity int, err error) {
+
+	units := 0
+	if property.RoomTypes != nil {
+		for _, roomType := range *property.RoomTypes {
+			units += roomType.Units
+		}
+	}
+	units = max(units, 1)
+
+	bookings, err := srv.bookingsRepository.GetBookingsForProperty(ctx, property.PropertyId)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, booking := range bookings {
+		from := laterOf(booking.StartDate.Time, startDate)
+		to := earlierOf(booking.EndDate.Time, endDate)
+		nights := len(database.NightsBetween(from, to))
+		if booking.Unit == nil {
+			nights *= units
+		}
+		booked += nights
+	}
+	capacity = units * len(database.NightsBetween(startDate, endDate))
+	return min(booked, capacity), capacity, nil
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,98 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"booking/internal/database"
+	"booking/internal/domain"
+)
+
+// errPromoCodeUsedUp reports a promo code redeemed the maximum times, also
+// when the limit is reached by the bookings made meanwhile.
+var errPromoCodeUsedUp = invalidPromoCode("The promo code has been used up.")
+
+// promotion returns the promotion of the promo code, nil without one,
+// failing with domain.ErrInvalidPromoCode if there is no such code or it
+// does not apply to the stay at the property booked now.
+func (srv *bookingsService) promotion(ctx context.Context, code *string, property domain.Property,
+	startDate, endDate time.Time) (*domain.Promotion, error) {
+
+	if code == nil {
+		return nil, nil
+	}
+	promotion, err := srv.promotionsRepository.GetPromotion(ctx, normalizeCode(*code))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, invalidPromoCode("There is no such promo code.")
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := srv.now()
+	nights := len(database.NightsBetween(startDate, endDate))
+	switch {
+	case promotion.ValidFrom != nil && now.Before(*promotion.ValidFrom):
+		return nil, invalidPromoCode(fmt.Sprintf("The promo code is valid from %s.",
+			promotion.ValidFrom.Format(time.DateOnly)))
+	case promotion.ValidUntil != nil && !now.Before(*promotion.ValidUntil):
+		return nil, invalidPromoCode("The promo code has expired.")
+	case !inScope(*promotion, property):
+		return nil, invalidPromoCode("The promo code does not apply to the property.")
+	case promotion.MinNights != nil && nights < *promotion.MinNights:
+		return nil, invalidPromoCode(fmt.Sprintf("The promo code applies to stays of at least %d nights.",
+			*promotion.MinNights))
+	case promotion.MaxRedemptions != nil && promotion.Redemptions >= *promotion.MaxRedemptions:
+		return nil, errPromoCodeUsedUp
+	}
+	return promotion, nil
+}
+
+// inScope tells whether the promotion applies to the property, by its id or
+// its city, all the properties being in the scope of one with neither.
+func inScope(promotion domain.Promotion, property domain.Property) bool {
+	if promotion.PropertyIds == nil && promotion.Cities == nil {
+		return true
+	}
+	if promotion.PropertyIds != nil && slices.Contains(*promotion.PropertyIds, property.PropertyId) {
+		return true
+	}
+	return promotion.Cities != nil && slices.ContainsFunc(*promotion.Cities, func(city string) bool {
+		return strings.EqualFold(city, property.City)
+	})
+}
+
+// discount returns the discount of the promotion off the price, none
+// without one, at most the whole price, rounded to the cent.
+func discount(promotion *domain.Promotion, price float32) float32 {
+	if promotion == nil {
+		return 0
+	}
+	var off float64
+	if promotion.DiscountPercent != nil {
+		off = float64(price) * float64(*promotion.DiscountPercent) / 100
+	} else if promotion.DiscountAmount != nil {
+		off = math.Min(float64(*promotion.DiscountAmount), float64(price))
+	}
+	return float32(math.Round(off*100) / 100)
+}
+
+// discounted returns the price with the discount of the promotion taken off.
+func discounted(promotion *domain.Promotion, price float32) float32 {
+	return float32(math.Round(float64(price-discount(promotion, price))*100) / 100)
+}
+
+// normalizeCode returns the promo code as the promotions are stored with,
+// matching it regardless of the case and of the surrounding spaces.
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func invalidPromoCode(message string) error {
+	return domain.NewValidationError(domain.ErrInvalidPromoCode,
+		domain.FieldError{Field: "promoCode", Message: message})
+}
@@ -0,0 +1,139 @@
+package bookings
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"booking/internal/database"
+	"booking/internal/domain"
+)
+
+// ratePlan returns the rate plan of the property the stay is priced with,
+// nil for the standard rate, failing with domain.ErrRatePlanNotFound if the
+// property has no such plan.
+func ratePlan(property domain.Property, ratePlanId *string) (*domain.RatePlan, error) {
+	if ratePlanId == nil {
+		return nil, nil
+	}
+	var ids []string
+	if property.RatePlans != nil {
+		for _, plan := range *property.RatePlans {
+			if plan.RatePlanId == *ratePlanId {
+				return &plan, nil
+			}
+			ids = append(ids, plan.RatePlanId)
+		}
+	}
+	message := "The property offers only the standard rate."
+	if len(ids) > 0 {
+		message = fmt.Sprintf("The property has the rate plans %s.", strings.Join(ids, ", "))
+	}
+	return nil, domain.NewValidationError(domain.ErrRatePlanNotFound,
+		domain.FieldError{Field: "ratePlanId", Message: message})
+}
+
+// offeredFor returns the room types the rate plan is offered for, all of
+// them for the standard rate, failing with domain.ErrRatePlanNotFound if it
+// is offered for none of them.
+func offeredFor(plan *domain.RatePlan, roomTypes []domain.RoomType) ([]domain.RoomType, error) {
+	if plan == nil || len(roomTypes) == 0 {
+		return roomTypes, nil
+	}
+	var offered []domain.RoomType
+	for _, roomType := range roomTypes {
+		if offers(*plan, roomType) {
+			offered = append(offered, roomType)
+		}
+	}
+	if len(offered) == 0 {
+		return nil, domain.NewValidationError(domain.ErrRatePlanNotFound, domain.FieldError{
+			Field:   "ratePlanId",
+			Message: fmt.Sprintf("The rate plan is offered only for the room types %s.", strings.Join(*plan.RoomTypeIds, ", ")),
+		})
+	}
+	return offered, nil
+}
+
+// offers tells whether the rate plan is offered for the room type.
+func offers(plan domain.RatePlan, roomType domain.RoomType) bool {
+	return plan.RoomTypeIds == nil || slices.Contains(*plan.RoomTypeIds, roomType.RoomTypeId)
+}
+
+// cancellation returns the terms of a stay booked now with the rate plan. A
+// refundable stay may be cancelled until the deadline of the plan before its
+// check-in, and is no longer refundable once the deadline has passed.
+func cancellation(plan domain.RatePlan, schedule schedule, now, startDate time.Time) domain.CancellationTerms {
+	if !plan.CancellationPolicy.Refundable {
+		return domain.CancellationTerms{}
+	}
+	days := 0
+	set(&days, plan.CancellationPolicy.DeadlineDays)
+	deadline := schedule.checkInAt(startDate).AddDate(0, 0, -days)
+	if !now.Before(deadline) {
+		return domain.CancellationTerms{}
+	}
+	return domain.CancellationTerms{Refundable: true, CancellableUntil: &deadline}
+}
+
+// quoteRatePlans prices the stay with the rate plans, for a property with
+// room types at the lowest price of the ones the plan is offered for with a
+// unit free. The plans with no such room type are left out.
+func quoteRatePlans(property domain.Property, plans []domain.RatePlan, roomTypes []domain.RoomType,
+	occupancy occupancy, party party, rates nightlyRates, schedule schedule,
+	now, startDate, endDate time.Time) []domain.RatePlanQuote {
+
+	quotes := []domain.RatePlanQuote{}
+	for _, plan := range plans {
+		quote := domain.RatePlanQuote{
+			RatePlanId:   plan.RatePlanId,
+			Name:         plan.Name,
+			Cancellation: cancellation(plan, schedule, now, startDate),
+		}
+		if len(roomTypes) == 0 {
+			quote.Price = calculatePrice(property, &plan, party, rates, startDate, endDate)
+			quotes = append(quotes, quote)
+			continue
+		}
+
+		offered := false
+		for _, roomType := range roomTypes {
+			if !offers(plan, roomType) || len(occupancy.freeUnits(roomType)) == 0 {
+				continue
+			}
+			price := calculatePrice(unitOf(property, roomType), &plan, party, rates, startDate, endDate)
+			if !offered || price < quote.Price {
+				quote.Price = price
+			}
+			offered = true
+		}
+		if offered {
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes
+}
+
+// calculatePrice prices the nights of the stay at their nightly rates, with
+// the fees of the party, with the rate plan, the standard rate if nil,
+// rounded to the cent.
+func calculatePrice(property domain.Property, plan *domain.RatePlan, party party, rates nightlyRates,
+	startDate, endDate time.Time) float32 {
+
+	var total float64
+	for _, date := range database.NightsBetween(startDate, endDate) {
+		night := float64(property.Size)*rates.factor(date) + float64(party.fees(property))
+		if plan != nil {
+			if plan.PriceAdjustmentPercent != nil {
+				night *= 1 + float64(*plan.PriceAdjustmentPercent)/100
+			}
+			if plan.GuestNightFee != nil {
+				night += float64(*plan.GuestNightFee) * float64(party.guests())
+			}
+		}
+		total += night
+	}
+	return float32(math.Round(total*100) / 100)
+}
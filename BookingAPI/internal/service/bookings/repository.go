@@ -5,6 +5,8 @@ import (
 	"context"
 )
 
+// The repositories report missing items with database.ErrNotFound.
+
 type bookingsRepository interface {
 	AddBooking(ctx context.Context, booking domain.Booking) error
 	GetBookingsForProperty(ctx context.Context, propertyId int) ([]domain.Booking, error)
@@ -12,6 +14,15 @@ type bookingsRepository interface {
 	RemoveBooking(ctx context.Context, bookingId string) error
 }
 
+type blocksRepository interface {
+	GetBlocksForProperty(ctx context.Context, propertyId int) ([]domain.Block, error)
+}
+
+type promotionsRepository interface {
+	GetPromotion(ctx context.Context, code string) (*domain.Promotion, error)
+}
+
 type propertiesRepository interface {
 	GetProperty(ctx context.Context, propertyId int) (*domain.Property, error)
+	Search(ctx context.Context, options domain.SearchOptions) ([]domain.Property, error)
 }
@@ -0,0 +1,101 @@
+package bookings
+
+import (
+	"fmt"
+	"strings"
+
+	"booking/internal/domain"
+)
+
+// occupancy is what holds the property during a stay: the property as a
+// whole, taken by a block or by a booking without a unit, or some units of
+// its room types.
+type occupancy struct {
+	whole bool
+	// the taken units by room type
+	units map[string]map[int]bool
+}
+
+func (o *occupancy) take(roomTypeId string, unit int) {
+	if o.units == nil {
+		o.units = map[string]map[int]bool{}
+	}
+	if o.units[roomTypeId] == nil {
+		o.units[roomTypeId] = map[int]bool{}
+	}
+	o.units[roomTypeId][unit] = true
+}
+
+// free tells whether the property is free for the stay as a whole.
+func (o occupancy) free() bool {
+	return !o.whole && len(o.units) == 0
+}
+
+// freeUnits returns the units of the room type free for the stay, in order.
+func (o occupancy) freeUnits(roomType domain.RoomType) []int {
+	if o.whole {
+		return nil
+	}
+	var free []int
+	for unit := 1; unit <= roomType.Units; unit++ {
+		if !o.units[roomType.RoomTypeId][unit] {
+			free = append(free, unit)
+		}
+	}
+	return free
+}
+
+// roomTypes returns the room types a stay at the property may take: the
+// given one, failing with domain.ErrRoomTypeNotFound if the property has no
+// such type, or all of them, none for a property booked as a whole.
+func roomTypes(property domain.Property, roomTypeId *string) ([]domain.RoomType, error) {
+	var all []domain.RoomType
+	if property.RoomTypes != nil {
+		all = *property.RoomTypes
+	}
+	if roomTypeId == nil {
+		return all, nil
+	}
+
+	ids := make([]string, len(all))
+	for i, roomType := range all {
+		if roomType.RoomTypeId == *roomTypeId {
+			return []domain.RoomType{roomType}, nil
+		}
+		ids[i] = roomType.RoomTypeId
+	}
+	message := "The property is booked as a whole, it has no room types."
+	if len(all) > 0 {
+		message = fmt.Sprintf("The property has the room types %s.", strings.Join(ids, ", "))
+	}
+	return nil, domain.NewValidationError(domain.ErrRoomTypeNotFound,
+		domain.FieldError{Field: "roomTypeId", Message: message})
+}
+
+// hosting returns the room types hosting the party, failing with the error
+// of the first one if none does.
+func hosting(property domain.Property, roomTypes []domain.RoomType, party party) ([]domain.RoomType, error) {
+	var hosting []domain.RoomType
+	var firstErr error
+	for _, roomType := range roomTypes {
+		if err := party.check(unitOf(property, roomType)); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		hosting = append(hosting, roomType)
+	}
+	if len(hosting) == 0 {
+		return nil, firstErr
+	}
+	return hosting, nil
+}
+
+// unitOf returns the property as a unit of the room type is: hosting the
+// guests of the unit and priced by its size.
+func unitOf(property domain.Property, roomType domain.RoomType) domain.Property {
+	property.Guests = roomType.Guests
+	property.Size = roomType.Size
+	return property
+}
@@ -0,0 +1,150 @@
+package bookings
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"booking/internal/database"
+	"booking/internal/domain"
+)
+
+const (
+	defaultMinNights          = 1
+	defaultMaxNights          = 30
+	defaultBookingHorizonDays = 365
+)
+
+// stayRules are the stay rules of a property, the defaults filled in. The
+// stays are at most database.MaxNights long, the longest the stores take,
+// whatever the rules of the property.
+type stayRules struct {
+	minNights, maxNights int
+	bookingHorizonDays   int
+	leadTimeDays         int
+	// any weekday if empty
+	arrivalWeekdays []time.Weekday
+	// until the end of the day if nil
+	sameDayCutoff *clock
+}
+
+func newStayRules(property domain.Property) (stayRules, error) {
+	rules := stayRules{
+		minNights:          defaultMinNights,
+		maxNights:          defaultMaxNights,
+		bookingHorizonDays: defaultBookingHorizonDays,
+	}
+	if property.StayRules == nil {
+		return rules, nil
+	}
+
+	configured := property.StayRules
+	set(&rules.minNights, configured.MinNights)
+	set(&rules.maxNights, configured.MaxNights)
+	rules.maxNights = min(rules.maxNights, database.MaxNights)
+	set(&rules.bookingHorizonDays, configured.BookingHorizonDays)
+	set(&rules.leadTimeDays, configured.LeadTimeDays)
+	if configured.ArrivalWeekdays != nil {
+		for _, name := range *configured.ArrivalWeekdays {
+			weekday, ok := parseWeekday(name)
+			if !ok {
+				return stayRules{}, fmt.Errorf("arrival weekday of property %d: unknown weekday %q",
+					property.PropertyId, name)
+			}
+			rules.arrivalWeekdays = append(rules.arrivalWeekdays, weekday)
+		}
+	}
+	if configured.SameDayCutoff != nil {
+		cutoff, err := parseClock(configured.SameDayCutoff, "")
+		if err != nil {
+			return stayRules{}, fmt.Errorf("same-day cutoff of property %d: %w", property.PropertyId, err)
+		}
+		rules.sameDayCutoff = &cutoff
+	}
+	return rules, nil
+}
+
+func set[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+func parseWeekday(name domain.Weekday) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if weekday.String() == string(name) {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// check returns the rules the stay breaks if it is booked now, the days
+// counted in the local time of the property. A stay starting in the past
+// breaks none of the rules about how far ahead it is booked.
+func (rules stayRules) check(schedule schedule, now, startDate, endDate time.Time) []domain.RuleViolation {
+	violations := []domain.RuleViolation{}
+	violate := func(rule domain.RuleViolationRule, field, format string, args ...any) {
+		violations = append(violations, domain.RuleViolation{
+			Rule:    rule,
+			Field:   field,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	today := schedule.today(now)
+	switch {
+	case startDate.Before(today):
+		violate(domain.StartInPast, "startDate", "The stay cannot start before today, %s in %s.",
+			today.Format(time.DateOnly), schedule.location)
+	case startDate.Before(today.AddDate(0, 0, rules.leadTimeDays)):
+		violate(domain.LeadTime, "startDate",
+			"Stays at the property are booked at least %s ahead, starting %s at the earliest.",
+			plural(rules.leadTimeDays, "day", "days"), today.AddDate(0, 0, rules.leadTimeDays).Format(time.DateOnly))
+	case startDate.Equal(today) && rules.sameDayCutoff != nil &&
+		!now.Before(schedule.at(today, *rules.sameDayCutoff)):
+
+		violate(domain.SameDayCutoff, "startDate", "Stays starting today are booked until %02d:%02d, %s time.",
+			rules.sameDayCutoff.hour, rules.sameDayCutoff.minute, schedule.location)
+	case startDate.After(today.AddDate(0, 0, rules.bookingHorizonDays)):
+		violate(domain.BookingHorizon, "startDate", "Stays at the property start at most %s ahead, by %s.",
+			plural(rules.bookingHorizonDays, "day", "days"),
+			today.AddDate(0, 0, rules.bookingHorizonDays).Format(time.DateOnly))
+	}
+
+	if len(rules.arrivalWeekdays) > 0 && !slices.Contains(rules.arrivalWeekdays, startDate.Weekday()) {
+		names := make([]string, len(rules.arrivalWeekdays))
+		for i, weekday := range rules.arrivalWeekdays {
+			names[i] = weekday.String()
+		}
+		violate(domain.ArrivalWeekday, "startDate", "Stays at the property start on %s only, not on %s.",
+			listOr(names), startDate.Weekday())
+	}
+
+	nights := int(endDate.Sub(startDate).Hours() / 24)
+	if nights < rules.minNights {
+		violate(domain.MinNights, "endDate", "Stays at the property are at least %s long.",
+			plural(rules.minNights, "night", "nights"))
+	}
+	if nights > rules.maxNights {
+		violate(domain.MaxNights, "endDate", "Stays at the property are at most %s long.",
+			plural(rules.maxNights, "night", "nights"))
+	}
+	return violations
+}
+
+// listOr lists the items as in "Friday, Saturday or Sunday".
+func listOr(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,111 @@
+package bookings
+
+import (
+	"fmt"
+	"time"
+
+	"booking/internal/domain"
+)
+
+const (
+	defaultCheckInTime  = "15:00"
+	defaultCheckOutTime = "11:00"
+)
+
+// schedule places the dates of a stay, days without a time zone in the API,
+// in the local time of the property: the stay holds the property from the
+// check-in on its start date to the check-out on its end date.
+type schedule struct {
+	location          *time.Location
+	checkIn, checkOut clock
+	// the nights left free before and after every stay
+	bufferBefore, bufferAfter int
+}
+
+// clock is a local time of the day, e.g. 15:00.
+type clock struct {
+	hour, minute int
+}
+
+func newSchedule(property domain.Property) (schedule, error) {
+	location, err := property.LoadLocation()
+	if err != nil {
+		return schedule{}, fmt.Errorf("time zone of property %d: %w", property.PropertyId, err)
+	}
+	checkIn, err := parseClock(property.CheckInTime, defaultCheckInTime)
+	if err != nil {
+		return schedule{}, fmt.Errorf("check-in time of property %d: %w", property.PropertyId, err)
+	}
+	checkOut, err := parseClock(property.CheckOutTime, defaultCheckOutTime)
+	if err != nil {
+		return schedule{}, fmt.Errorf("check-out time of property %d: %w", property.PropertyId, err)
+	}
+	schedule := schedule{location: location, checkIn: checkIn, checkOut: checkOut}
+	set(&schedule.bufferBefore, property.BufferNightsBefore)
+	set(&schedule.bufferAfter, property.BufferNightsAfter)
+	return schedule, nil
+}
+
+func parseClock(value *string, defaultValue string) (clock, error) {
+	if value == nil || *value == "" {
+		value = &defaultValue
+	}
+	parsed, err := time.Parse("15:04", *value)
+	if err != nil {
+		return clock{}, err
+	}
+	return clock{hour: parsed.Hour(), minute: parsed.Minute()}, nil
+}
+
+// checkInAt returns the time the stay starting on the date may check in from.
+func (s schedule) checkInAt(date time.Time) time.Time {
+	return s.at(date, s.checkIn)
+}
+
+// checkOutAt returns the time the stay ending on the date checks out by.
+func (s schedule) checkOutAt(date time.Time) time.Time {
+	return s.at(date, s.checkOut)
+}
+
+// at builds the local time from the fields, not by adding a duration to the
+// midnight, so that it stays the same on the days the clocks change.
+func (s schedule) at(date time.Time, c clock) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), c.hour, c.minute, 0, 0, s.location)
+}
+
+// today returns the local date of the property at the time, as a midnight in
+// UTC like the dates of the API.
+func (s schedule) today(now time.Time) time.Time {
+	local := now.In(s.location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// overlap tells whether the stays hold the property at the same time. Two
+// stays may meet on a day if the check-out is before the check-in. The
+// stores keep only the nights of the stays apart, so a check-out after the
+// check-in is enforced here alone.
+func (s schedule) overlap(startDate1, endDate1, startDate2, endDate2 time.Time) bool {
+	return s.checkInAt(startDate1).Before(s.checkOutAt(endDate2)) &&
+		s.checkOutAt(endDate1).After(s.checkInAt(startDate2))
+}
+
+// conflict tells whether the stay cannot be booked along with the booked
+// one: whether they overlap or either of them falls into the buffer nights
+// of the other. So the gap between two stays is the larger of the buffers.
+func (s schedule) conflict(startDate, endDate, bookedStartDate, bookedEndDate time.Time) bool {
+	before, after := -s.bufferBefore, s.bufferAfter
+	return s.overlap(startDate, endDate, bookedStartDate.AddDate(0, 0, before), bookedEndDate.AddDate(0, 0, after)) ||
+		s.overlap(startDate.AddDate(0, 0, before), endDate.AddDate(0, 0, after), bookedStartDate, bookedEndDate)
+}
+
+// instructions tells the guests when to arrive and leave, followed by the
+// access instructions of the property.
+func (s schedule) instructions(property domain.Property, startDate, endDate time.Time) string {
+	instructions := fmt.Sprintf("Check-in from %s on %s, check-out by %s on %s, %s time.",
+		s.checkInAt(startDate).Format("15:04"), startDate.Format("Monday 2006-01-02"),
+		s.checkOutAt(endDate).Format("15:04"), endDate.Format("Monday 2006-01-02"), s.location)
+	if property.AccessInstructions != nil && *property.AccessInstructions != "" {
+		instructions += " " + *property.AccessInstructions
+	}
+	return instructions
+}
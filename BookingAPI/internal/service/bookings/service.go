@@ -2,116 +2,460 @@ package bookings
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"booking/internal/database"
 	"booking/internal/domain"
+	"booking/internal/logging"
+	"booking/internal/metrics"
+	"booking/internal/tracing"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var errInvalidDateRange = domain.NewValidationError(domain.ErrInvalidDateRange,
+	domain.FieldError{Field: "endDate", Message: "End date should be after start date."})
+
 type bookingsService struct {
 	bookingsRepository   bookingsRepository
+	blocksRepository     blocksRepository
+	promotionsRepository promotionsRepository
 	propertiesRepository propertiesRepository
+	recentOccupancy      *occupancyCache
+	now                  func() time.Time
 }
 
-func NewService(bookingsRepository bookingsRepository,
-	propertiesRepository propertiesRepository) *bookingsService {
+func NewService(bookingsRepository bookingsRepository, blocksRepository blocksRepository,
+	promotionsRepository promotionsRepository, propertiesRepository propertiesRepository) *bookingsService {
 
 	return &bookingsService{
 		bookingsRepository:   bookingsRepository,
+		blocksRepository:     blocksRepository,
+		promotionsRepository: promotionsRepository,
 		propertiesRepository: propertiesRepository,
+		recentOccupancy:      newOccupancyCache(),
+		now:                  time.Now,
 	}
 }
 
-func (srv *bookingsService) BookProperty(ctx context.Context, request domain.BookingRequest) (domain.BookingResponse, error) {
+// BookProperty books the property as a whole or, for a property with room
+// types, a unit of the requested room type or of the first one hosting the
+// guests with a unit free for the stay. The stay is priced with the requested
+// rate plan, only the room types it is offered for being booked, or with the
+// standard rate. The promo code, if any, is redeemed along with the booking
+// and its discount taken off the total amount.
+func (srv *bookingsService) BookProperty(ctx context.Context, request domain.BookingRequest) (
+	_ domain.BookingResponse, err error) {
+
+	ctx, span := tracing.Start(ctx, "bookingsService.BookProperty",
+		attribute.Int("property.id", request.PropertyId))
+	defer tracing.End(span, &err)
+
+	if !request.EndDate.After(request.StartDate.Time) {
+		return domain.BookingResponse{}, errInvalidDateRange
+	}
+
 	property, err := srv.propertiesRepository.GetProperty(ctx, request.PropertyId)
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.BookingResponse{}, domain.ErrPropertyNotFound
+	} else if err != nil {
+		return domain.BookingResponse{}, err
+	}
+	schedule, violations, err := srv.checkRules(*property, request.StartDate.Time, request.EndDate.Time)
+	if err != nil {
+		return domain.BookingResponse{}, err
+	} else if len(violations) > 0 {
+		return domain.BookingResponse{}, &domain.StayRulesError{Violations: violations}
+	}
+	plan, err := ratePlan(*property, request.RatePlanId)
+	if err != nil {
+		return domain.BookingResponse{}, err
+	}
+	promotion, err := srv.promotion(ctx, request.PromoCode, *property, request.StartDate.Time, request.EndDate.Time)
+	if err != nil {
+		return domain.BookingResponse{}, err
+	}
+	if promotion != nil {
+		request.PromoCode = &promotion.Code
+	}
+	types, err := roomTypes(*property, request.RoomTypeId)
+	if err != nil {
+		return domain.BookingResponse{}, err
+	}
+	types, err = offeredFor(plan, types)
+	if err != nil {
+		return domain.BookingResponse{}, err
+	}
+	party := newParty(request.Guests)
+	if len(types) == 0 {
+		err = party.check(*property)
+	} else {
+		types, err = hosting(*property, types, party)
+	}
 	if err != nil {
 		return domain.BookingResponse{}, err
-	} else if property == nil {
-		return domain.BookingResponse{}, domain.ErrPropertyNotFound
 	}
+	request.Guests = party.toGuests()
 
-	availability, err := srv.GetAvailability(ctx, request.PropertyId, request.StartDate.Time, request.EndDate.Time)
+	city := metrics.Dimension{Name: "City", Value: property.City}
+	occupancy, err := srv.occupancy(ctx, schedule, request.PropertyId, request.StartDate.Time, request.EndDate.Time)
+	if err != nil {
+		return domain.BookingResponse{}, err
+	}
+	rates, err := srv.nightlyRates(ctx, *property, schedule)
 	if err != nil {
 		return domain.BookingResponse{}, err
-	} else if !availability.Available {
-		return domain.BookingResponse{}, domain.ErrPropertyNotAvailable
 	}
 
 	bookingID := uuid.New()
+	span.SetAttributes(attribute.String("booking.id", bookingID.String()))
 	booking := domain.Booking{
 		BookingRequest: request,
 		BookingId:      bookingID.String(),
 	}
-
-	err = srv.bookingsRepository.AddBooking(ctx, booking)
-	if err != nil {
+	if plan != nil {
+		terms := cancellation(*plan, schedule, srv.now(), request.StartDate.Time)
+		booking.Cancellation = &terms
+	}
+	booked, err := srv.allocate(ctx, *property, types, occupancy, booking)
+	if errors.Is(err, domain.ErrPropertyNotAvailable) {
+		metrics.Count(ctx, metrics.BookingConflicts, city)
+		return domain.BookingResponse{}, err
+	} else if errors.Is(err, database.ErrLimitReached) {
+		// redeemed the maximum times by the bookings made meanwhile
+		return domain.BookingResponse{}, errPromoCodeUsedUp
+	} else if err != nil {
 		return domain.BookingResponse{}, err
 	}
+	booking = booked.booking
+	logging.FromContext(ctx).Info("booking created", "booking", booking)
 
+	price := calculatePrice(booked.unit, plan, party, rates, request.StartDate.Time, request.EndDate.Time)
+	var off *float32
+	if promotion != nil {
+		amount := discount(promotion, price)
+		off = &amount
+		price = discounted(promotion, price)
+	}
+	metrics.Count(ctx, metrics.BookingsCreated, city)
+	metrics.Record(ctx, metrics.BookingValue, float64(price), metrics.UnitNone, city)
+
+	instructions := schedule.instructions(*property, request.StartDate.Time, request.EndDate.Time)
 	return domain.BookingResponse{
-		BookingId:    bookingID,
-		PropertyId:   request.PropertyId,
-		CustomerName: request.CustomerName,
-		StartDate:    request.StartDate,
-		EndDate:      request.EndDate,
-		TotalAmount:  calculatePrice(*property, request.StartDate.Time, request.EndDate.Time),
+		BookingId:           bookingID,
+		PropertyId:          request.PropertyId,
+		CustomerName:        request.CustomerName,
+		StartDate:           request.StartDate,
+		EndDate:             request.EndDate,
+		Guests:              request.Guests,
+		RoomTypeId:          booking.RoomTypeId,
+		Unit:                booking.Unit,
+		RatePlanId:          request.RatePlanId,
+		Cancellation:        booking.Cancellation,
+		PromoCode:           booking.PromoCode,
+		Discount:            off,
+		TotalAmount:         price,
+		CheckInInstructions: &instructions,
 	}, nil
 }
 
-func (srv *bookingsService) GetAvailability(ctx context.Context, propertyId int, startDate, endDate time.Time) (domain.Availability, error) {
+// allocation is a stored booking along with the property as the booked unit
+// of it is, see unitOf.
+type allocation struct {
+	booking domain.Booking
+	unit    domain.Property
+}
+
+// allocate stores the booking of the property as a whole or, for the room
+// types given, of the first unit free for the stay. A unit taken meanwhile by
+// another booking is skipped for the next one. It fails with
+// domain.ErrPropertyNotAvailable if there is no free unit left.
+func (srv *bookingsService) allocate(ctx context.Context, property domain.Property, types []domain.RoomType,
+	occupancy occupancy, booking domain.Booking) (allocation, error) {
+
+	if len(types) == 0 {
+		if !occupancy.free() {
+			return allocation{}, domain.ErrPropertyNotAvailable
+		}
+		// the store rejects the booking if another one took the nights meanwhile
+		err := srv.bookingsRepository.AddBooking(ctx, booking)
+		if errors.Is(err, database.ErrOverlap) {
+			return allocation{}, domain.ErrPropertyNotAvailable
+		}
+		return allocation{booking: booking, unit: property}, err
+	}
+
+	for _, roomType := range types {
+		for _, unit := range occupancy.freeUnits(roomType) {
+			booking.RoomTypeId = &roomType.RoomTypeId
+			booking.Unit = &unit
+			err := srv.bookingsRepository.AddBooking(ctx, booking)
+			if errors.Is(err, database.ErrOverlap) {
+				continue
+			}
+			return allocation{booking: booking, unit: unitOf(property, roomType)}, err
+		}
+	}
+	return allocation{}, domain.ErrPropertyNotAvailable
+}
+
+// GetAvailability tells whether the property is available for the stay of
+// the guests, 1 adult if nil, and its price with the rate plan, the standard
+// rate if nil. For a property with room types, the room types hosting the
+// guests, or the given one, are checked one by one. The available stay is
+// also quoted with the rate plans of the property, or the given one. The
+// nights are priced at the dynamic rates of a property with dynamic pricing,
+// like the bookings. All the prices are with the discount of the promo code,
+// if any, taken off.
+func (srv *bookingsService) GetAvailability(ctx context.Context, propertyId int, startDate, endDate time.Time,
+	guests *domain.Guests, roomTypeId, ratePlanId, promoCode *string) (_ domain.Availability, err error) {
+
+	ctx, span := tracing.Start(ctx, "bookingsService.GetAvailability",
+		attribute.Int("property.id", propertyId))
+	defer tracing.End(span, &err)
+
+	if !endDate.After(startDate) {
+		return domain.Availability{}, errInvalidDateRange
+	}
+
 	property, err := srv.propertiesRepository.GetProperty(ctx, propertyId)
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.Availability{}, domain.ErrPropertyNotFound
+	} else if err != nil {
+		return domain.Availability{}, err
+	}
+	schedule, violations, err := srv.checkRules(*property, startDate, endDate)
+	if err != nil {
+		return domain.Availability{}, err
+	}
+	plan, err := ratePlan(*property, ratePlanId)
+	if err != nil {
+		return domain.Availability{}, err
+	}
+	promotion, err := srv.promotion(ctx, promoCode, *property, startDate, endDate)
+	if err != nil {
+		return domain.Availability{}, err
+	}
+	types, err := roomTypes(*property, roomTypeId)
+	if err != nil {
+		return domain.Availability{}, err
+	}
+	types, err = offeredFor(plan, types)
+	if err != nil {
+		return domain.Availability{}, err
+	}
+	party := newParty(guests)
+	if len(types) == 0 {
+		err = party.check(*property)
+	} else {
+		types, err = hosting(*property, types, party)
+	}
 	if err != nil {
 		return domain.Availability{}, err
-	} else if property == nil {
-		return domain.Availability{}, domain.ErrPropertyNotFound
 	}
 
-	bookings, err := srv.bookingsRepository.GetBookingsForProperty(ctx, propertyId)
+	city := metrics.Dimension{Name: "City", Value: property.City}
+	metrics.Count(ctx, metrics.AvailabilityChecks, city)
+	if len(violations) > 0 {
+		return domain.Availability{
+			Available:  false,
+			Violations: &violations,
+		}, nil
+	}
+
+	occupancy, err := srv.occupancy(ctx, schedule, propertyId, startDate, endDate)
+	if err != nil {
+		return domain.Availability{}, err
+	}
+	rates, err := srv.nightlyRates(ctx, *property, schedule)
 	if err != nil {
 		return domain.Availability{}, err
 	}
+	availability := domain.Availability{Available: occupancy.free()}
+	if len(types) == 0 && availability.Available {
+		availability.Price = calculatePrice(*property, plan, party, rates, startDate, endDate)
+	}
+	if len(types) > 0 {
+		availability = roomTypesAvailability(*property, plan, types, occupancy, party, rates, startDate, endDate)
+	}
+	if !availability.Available {
+		return availability, nil
+	}
+	metrics.Count(ctx, metrics.AvailabilityHits, city)
 
-	for _, booking := range bookings {
-		if bookingsOverlap(startDate, endDate, booking.StartDate.Time, booking.EndDate.Time) {
-			return domain.Availability{
-				Available: false,
-			}, nil
+	var plans []domain.RatePlan
+	if plan != nil {
+		plans = []domain.RatePlan{*plan}
+	} else if property.RatePlans != nil {
+		plans = *property.RatePlans
+	}
+	if len(plans) > 0 {
+		quotes := quoteRatePlans(*property, plans, types, occupancy, party, rates, schedule, srv.now(),
+			startDate, endDate)
+		availability.RatePlans = &quotes
+	}
+	if promotion != nil {
+		applyDiscount(&availability, promotion)
+	}
+	return availability, nil
+}
+
+// applyDiscount takes the discount of the promotion off all the prices of
+// the availability.
+func applyDiscount(availability *domain.Availability, promotion *domain.Promotion) {
+	off := discount(promotion, availability.Price)
+	availability.Discount = &off
+	availability.Price = discounted(promotion, availability.Price)
+	if availability.RoomTypes != nil {
+		for i, roomType := range *availability.RoomTypes {
+			(*availability.RoomTypes)[i].Price = discounted(promotion, roomType.Price)
+		}
+	}
+	if availability.RatePlans != nil {
+		for i, quote := range *availability.RatePlans {
+			(*availability.RatePlans)[i].Price = discounted(promotion, quote.Price)
 		}
 	}
+}
 
-	return domain.Availability{
-		Available: true,
-		Price:     calculatePrice(*property, startDate, endDate),
-	}, nil
+// roomTypesAvailability lists the availability of the room types with the
+// rate plan, the stay being available at the lowest price of the available
+// ones.
+func roomTypesAvailability(property domain.Property, plan *domain.RatePlan, types []domain.RoomType,
+	occupancy occupancy, party party, rates nightlyRates, startDate, endDate time.Time) domain.Availability {
+
+	availability := domain.Availability{}
+	list := make([]domain.RoomTypeAvailability, len(types))
+	for i, roomType := range types {
+		free := len(occupancy.freeUnits(roomType))
+		price := calculatePrice(unitOf(property, roomType), plan, party, rates, startDate, endDate)
+		list[i] = domain.RoomTypeAvailability{
+			RoomTypeId:     roomType.RoomTypeId,
+			Name:           roomType.Name,
+			Available:      free > 0,
+			UnitsAvailable: free,
+			Price:          price,
+		}
+		if free > 0 && (!availability.Available || price < availability.Price) {
+			availability.Available = true
+			availability.Price = price
+		}
+	}
+	availability.RoomTypes = &list
+	return availability
 }
 
-func (srv *bookingsService) Cancel(ctx context.Context, bookingId uuid.UUID) error {
-	booking, err := srv.bookingsRepository.GetBooking(ctx, bookingId.String())
+// IsAvailable tells whether the stay at the property may be booked now, by
+// the stay rules, the bookings and the blocks of it, any unit of a property
+// with room types being enough. Unlike GetAvailability, it takes the property
+// already fetched and counts no availability checks, as it filters the
+// results of the search.
+func (srv *bookingsService) IsAvailable(ctx context.Context, property domain.Property,
+	startDate, endDate time.Time) (bool, error) {
+
+	schedule, violations, err := srv.checkRules(property, startDate, endDate)
+	if err != nil || len(violations) > 0 {
+		return false, err
+	}
+	occupancy, err := srv.occupancy(ctx, schedule, property.PropertyId, startDate, endDate)
 	if err != nil {
-		return err
-	} else if booking == nil {
-		return domain.ErrBookingNotFound
+		return false, err
+	}
+	types, _ := roomTypes(property, nil)
+	if len(types) == 0 {
+		return occupancy.free(), nil
+	}
+	for _, roomType := range types {
+		if len(occupancy.freeUnits(roomType)) > 0 {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	err = srv.bookingsRepository.RemoveBooking(ctx, bookingId.String())
+// checkRules returns the schedule of the property and the stay rules of it
+// the stay breaks if it is booked now.
+func (srv *bookingsService) checkRules(property domain.Property, startDate, endDate time.Time) (
+	schedule, []domain.RuleViolation, error) {
+
+	schedule, err := newSchedule(property)
 	if err != nil {
-		switch err.Error() {
-		case database.ErrorNotFound:
-			return domain.ErrBookingNotFound
-		default:
-			return err
-		}
+		return schedule, nil, err
 	}
-	return nil
+	rules, err := newStayRules(property)
+	if err != nil {
+		return schedule, nil, err
+	}
+	return schedule, rules.check(schedule, srv.now(), startDate, endDate), nil
 }
 
-func calculatePrice(property domain.Property, startDate, endDate time.Time) float32 {
-	daysCount := int(endDate.Sub(startDate).Hours() / 24)
-	return float32(property.Size * daysCount)
+// occupancy returns what holds the property during the stay in the local
+// time of the property: the bookings conflicting with it and the blocks
+// overlapping it, the blocks taking the property from the check-in on their
+// start date to the check-out on their end date. The buffer nights of the
+// property are kept between the stays, not around the blocks. The buffers
+// are best-effort: the stores keep only the nights of the bookings apart, so
+// a booking made meanwhile may still end up within the buffer.
+func (srv *bookingsService) occupancy(ctx context.Context, schedule schedule, propertyId int,
+	startDate, endDate time.Time) (occupancy, error) {
+
+	bookings, err := srv.bookingsRepository.GetBookingsForProperty(ctx, propertyId)
+	if err != nil {
+		return occupancy{}, err
+	}
+
+	var taken occupancy
+	for _, booking := range bookings {
+		if !schedule.conflict(startDate, endDate, booking.StartDate.Time, booking.EndDate.Time) {
+			continue
+		}
+		if booking.RoomTypeId == nil || booking.Unit == nil {
+			taken.whole = true
+		} else {
+			taken.take(*booking.RoomTypeId, *booking.Unit)
+		}
+	}
+
+	blocks, err := srv.blocksRepository.GetBlocksForProperty(ctx, propertyId)
+	if err != nil {
+		return occupancy{}, err
+	}
+	for _, block := range blocks {
+		if schedule.overlap(startDate, endDate, block.StartDate.Time, block.EndDate.Time) {
+			taken.whole = true
+		}
+	}
+	return taken, nil
 }
 
-func bookingsOverlap(startDate1, endDate1, startDate2, endDate2 time.Time) bool {
-	return startDate1.Before(endDate2) && endDate1.After(startDate2)
+// Cancel cancels the booking, failing with domain.ErrNotCancellable if the
+// terms of its rate plan no longer allow it.
+func (srv *bookingsService) Cancel(ctx context.Context, bookingId uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "bookingsService.Cancel",
+		attribute.String("booking.id", bookingId.String()))
+	defer tracing.End(span, &err)
+
+	booking, err := srv.bookingsRepository.GetBooking(ctx, bookingId.String())
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.ErrBookingNotFound
+	} else if err != nil {
+		return err
+	}
+	if terms := booking.Cancellation; terms != nil &&
+		(!terms.Refundable || terms.CancellableUntil == nil || !srv.now().Before(*terms.CancellableUntil)) {
+		return domain.ErrNotCancellable
+	}
+
+	// the booking may have been removed in the meantime
+	err = srv.bookingsRepository.RemoveBooking(ctx, bookingId.String())
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.ErrBookingNotFound
+	} else if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("booking cancelled")
+	metrics.Count(ctx, metrics.BookingsCancelled)
+	return nil
 }
@@ -0,0 +1,840 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+	"booking/internal/metrics"
+
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func TestBookPropertyMetrics(t *testing.T) {
+	recorder := metrics.NewMemory()
+	metrics.SetDefault(recorder)
+	t.Cleanup(func() { metrics.SetDefault(metrics.Nop) })
+
+	property := domain.Property{PropertyId: 1, City: "Krakow", Size: 55, Guests: 4}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	ctx := context.Background()
+
+	start := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return start }
+	request := domain.BookingRequest{
+		PropertyId: 1,
+		StartDate:  openapi_types.Date{Time: start},
+		EndDate:    openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+	}
+	if _, err := srv.BookProperty(ctx, request); err != nil {
+		t.Fatalf("BookProperty() error = %v", err)
+	}
+	if _, err := srv.BookProperty(ctx, request); err != domain.ErrPropertyNotAvailable {
+		t.Fatalf("BookProperty() error = %v, want %v", err, domain.ErrPropertyNotAvailable)
+	}
+	if _, err := srv.GetAvailability(ctx, 1, start.AddDate(0, 0, 2), start.AddDate(0, 0, 3), nil, nil, nil, nil); err != nil {
+		t.Fatalf("GetAvailability() error = %v", err)
+	}
+
+	krakow := metrics.Dimension{Name: "City", Value: "Krakow"}
+	for name, want := range map[string]float64{
+		metrics.BookingsCreated:    1,
+		metrics.BookingConflicts:   1,
+		metrics.BookingValue:       110,
+		metrics.AvailabilityChecks: 1,
+		metrics.AvailabilityHits:   1,
+	} {
+		if sum := recorder.Sum(name, krakow); sum != want {
+			t.Errorf("Sum(%s) = %v, want %v", name, sum, want)
+		}
+	}
+}
+
+func TestBookPropertyLocalTime(t *testing.T) {
+	auckland, access := "Pacific/Auckland", "Keyless entry"
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, TimeZone: &auckland, AccessInstructions: &access}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	// still July 1 in UTC, already July 2 in Auckland
+	srv.now = func() time.Time { return time.Date(2024, time.July, 1, 13, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	book := func(start time.Time) (domain.BookingResponse, error) {
+		return srv.BookProperty(ctx, domain.BookingRequest{
+			PropertyId: 1,
+			StartDate:  openapi_types.Date{Time: start},
+			EndDate:    openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+		})
+	}
+
+	july1 := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	var rulesErr *domain.StayRulesError
+	if _, err := book(july1); !errors.As(err, &rulesErr) || rulesErr.Violations[0].Rule != domain.StartInPast {
+		t.Fatalf("BookProperty() error = %v, want the start in the past", err)
+	}
+	confirmation, err := book(july1.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("BookProperty() error = %v", err)
+	}
+	want := "Check-in from 15:00 on Tuesday 2024-07-02, check-out by 11:00 on Thursday 2024-07-04, " +
+		"Pacific/Auckland time. Keyless entry"
+	if confirmation.CheckInInstructions == nil || *confirmation.CheckInInstructions != want {
+		t.Errorf("BookProperty() instructions = %v, want %q", confirmation.CheckInInstructions, want)
+	}
+}
+
+func TestScheduleOverlap(t *testing.T) {
+	july := func(day int) time.Time { return time.Date(2024, time.July, day, 0, 0, 0, 0, time.UTC) }
+	late := "16:00"
+	warsaw := "Europe/Warsaw"
+
+	for _, tc := range []struct {
+		name     string
+		property domain.Property
+		start    time.Time
+		want     bool
+	}{
+		{"same-day turnover", domain.Property{TimeZone: &warsaw}, july(5), false},
+		{"the last night", domain.Property{TimeZone: &warsaw}, july(4), true},
+		{"a day apart", domain.Property{TimeZone: &warsaw}, july(6), false},
+		{"late check-out", domain.Property{TimeZone: &warsaw, CheckOutTime: &late}, july(5), true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := newSchedule(tc.property)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the booked stay is July 3-5
+			if got := schedule.overlap(tc.start, tc.start.AddDate(0, 0, 2), july(3), july(5)); got != tc.want {
+				t.Errorf("overlap() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScheduleConflict(t *testing.T) {
+	july := func(day int) time.Time { return time.Date(2024, time.July, day, 0, 0, 0, 0, time.UTC) }
+	buffers := func(before, after int) domain.Property {
+		return domain.Property{BufferNightsBefore: &before, BufferNightsAfter: &after}
+	}
+	late := "16:00"
+	lateCheckOut := buffers(0, 1)
+	lateCheckOut.CheckOutTime = &late
+
+	for _, tc := range []struct {
+		name       string
+		property   domain.Property
+		start, end int
+		want       bool
+	}{
+		{"same-day turnover without buffers", domain.Property{}, 13, 15, false},
+		{"within the buffer after", buffers(0, 1), 13, 15, true},
+		{"past the buffer after", buffers(0, 1), 14, 16, false},
+		{"buffer after the new stay", buffers(0, 1), 7, 10, true},
+		{"past the buffer after the new stay", buffers(0, 1), 7, 9, false},
+		{"within the buffer before", buffers(2, 0), 14, 16, true},
+		{"past the buffer before", buffers(2, 0), 15, 17, false},
+		{"buffer before the booked stay", buffers(2, 0), 6, 9, true},
+		{"the larger of the buffers", buffers(1, 2), 14, 16, true},
+		{"past both buffers", buffers(1, 2), 15, 17, false},
+		{"buffer ending after the check-in", lateCheckOut, 14, 16, true},
+		{"overlapping stays", buffers(0, 0), 11, 12, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := newSchedule(tc.property)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the booked stay is July 10-13
+			if got := schedule.conflict(july(tc.start), july(tc.end), july(10), july(13)); got != tc.want {
+				t.Errorf("conflict() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBookPropertyBuffer(t *testing.T) {
+	after := 1
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, BufferNightsAfter: &after}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	today := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return today }
+	email := "john.doe@example.com"
+	book := func(start, end int) error {
+		_, err := srv.BookProperty(context.Background(), domain.BookingRequest{
+			PropertyId:     1,
+			CustomerName:   "John Doe",
+			StartDate:      openapi_types.Date{Time: today.AddDate(0, 0, start)},
+			EndDate:        openapi_types.Date{Time: today.AddDate(0, 0, end)},
+			ContactDetails: domain.ContactDetails{Email: &email},
+		})
+		return err
+	}
+
+	if err := book(10, 13); err != nil {
+		t.Fatalf("BookProperty() error = %v", err)
+	}
+	availability, err := srv.GetAvailability(context.Background(), 1,
+		today.AddDate(0, 0, 13), today.AddDate(0, 0, 15), nil, nil, nil, nil)
+	if err != nil || availability.Available {
+		t.Errorf("GetAvailability() = %+v, %v, want the buffer night taken", availability, err)
+	}
+	if err := book(13, 15); !errors.Is(err, domain.ErrPropertyNotAvailable) {
+		t.Errorf("BookProperty() error = %v, want %v", err, domain.ErrPropertyNotAvailable)
+	}
+	if err := book(14, 16); err != nil {
+		t.Errorf("BookProperty() error = %v, want the stay past the buffer booked", err)
+	}
+}
+
+func TestStayRulesCheck(t *testing.T) {
+	minNights, maxNights, horizon, leadTime, cutoff, unbounded := 2, 7, 30, 1, "18:00", 365
+	weekdays := []domain.Weekday{domain.Friday, domain.Saturday}
+	property := domain.Property{StayRules: &domain.StayRules{
+		MinNights:          &minNights,
+		MaxNights:          &maxNights,
+		BookingHorizonDays: &horizon,
+		LeadTimeDays:       &leadTime,
+		ArrivalWeekdays:    &weekdays,
+	}}
+	sameDay := domain.Property{StayRules: &domain.StayRules{SameDayCutoff: &cutoff}}
+	longStays := domain.Property{StayRules: &domain.StayRules{MaxNights: &unbounded}}
+	// Wednesday
+	now := time.Date(2024, time.July, 3, 19, 0, 0, 0, time.UTC)
+	july := func(day int) time.Time { return time.Date(2024, time.July, day, 0, 0, 0, 0, time.UTC) }
+
+	for _, tc := range []struct {
+		name       string
+		property   domain.Property
+		start, end time.Time
+		want       []domain.RuleViolationRule
+	}{
+		{"allowed", property, july(5), july(8), nil},
+		{"defaults", domain.Property{}, july(3), july(4), nil},
+		{"in the past", property, july(2), july(5), []domain.RuleViolationRule{domain.StartInPast, domain.ArrivalWeekday}},
+		{"lead time", property, july(3), july(5), []domain.RuleViolationRule{domain.LeadTime, domain.ArrivalWeekday}},
+		{"beyond horizon", property, july(3).AddDate(0, 0, 31), july(3).AddDate(0, 0, 33),
+			[]domain.RuleViolationRule{domain.BookingHorizon}},
+		{"arrival weekday", property, july(7), july(9), []domain.RuleViolationRule{domain.ArrivalWeekday}},
+		{"too short", property, july(5), july(6), []domain.RuleViolationRule{domain.MinNights}},
+		{"too long", property, july(5), july(13), []domain.RuleViolationRule{domain.MaxNights}},
+		{"default max nights", domain.Property{}, july(5), july(5).AddDate(0, 0, 31),
+			[]domain.RuleViolationRule{domain.MaxNights}},
+		{"longest stored stay", longStays, july(5), july(5).AddDate(0, 0, 99),
+			[]domain.RuleViolationRule{domain.MaxNights}},
+		{"after the cutoff", sameDay, july(3), july(4), []domain.RuleViolationRule{domain.SameDayCutoff}},
+		{"tomorrow after the cutoff", sameDay, july(4), july(5), nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := newSchedule(tc.property)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rules, err := newStayRules(tc.property)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []domain.RuleViolationRule
+			for _, violation := range rules.check(schedule, now, tc.start, tc.end) {
+				got = append(got, violation.Rule)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("check() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetAvailabilityStayRules(t *testing.T) {
+	minNights := 3
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, StayRules: &domain.StayRules{MinNights: &minNights}}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	start := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return start }
+
+	availability, err := srv.GetAvailability(context.Background(), 1, start, start.AddDate(0, 0, 2), nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("GetAvailability() error = %v", err)
+	}
+	if availability.Available || availability.Violations == nil || len(*availability.Violations) != 1 ||
+		(*availability.Violations)[0].Message != "Stays at the property are at least 3 nights long." {
+		t.Errorf("GetAvailability() = %+v, want the minimum nights broken", availability)
+	}
+}
+
+func TestGetAvailabilityBlocked(t *testing.T) {
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	today := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return today }
+	date := func(days int) openapi_types.Date {
+		return openapi_types.Date{Time: today.AddDate(0, 0, days)}
+	}
+	err := bookingsStore.AddBlock(context.Background(), domain.Block{
+		BlockId: uuid.New(), PropertyId: 1, StartDate: date(10), EndDate: date(15), Reason: "Maintenance",
+	})
+	if err != nil {
+		t.Fatalf("AddBlock() error = %v", err)
+	}
+
+	for _, tc := range []struct {
+		start, end int
+		available  bool
+	}{
+		{5, 10, true},
+		{5, 11, false},
+		{12, 13, false},
+		{14, 20, false},
+		{15, 20, true},
+	} {
+		availability, err := srv.GetAvailability(context.Background(), 1,
+			date(tc.start).Time, date(tc.end).Time, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("GetAvailability() error = %v", err)
+		}
+		if availability.Available != tc.available {
+			t.Errorf("GetAvailability(+%d, +%d days).Available = %v, want %v",
+				tc.start, tc.end, availability.Available, tc.available)
+		}
+		available, err := srv.IsAvailable(context.Background(), property, date(tc.start).Time, date(tc.end).Time)
+		if err != nil || available != tc.available {
+			t.Errorf("IsAvailable(+%d, +%d days) = %v, %v, want %v", tc.start, tc.end, available, err, tc.available)
+		}
+	}
+}
+
+func TestBookPropertyGuests(t *testing.T) {
+	included, maxPets := 2, 1
+	var extraGuestFee, petFee float32 = 15, 10
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, IncludedGuests: &included,
+		ExtraGuestFee: &extraGuestFee, MaxPets: &maxPets, PetFee: &petFee}
+	start := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	guests := func(adults, children, infants, pets int) *domain.Guests {
+		return &domain.Guests{Adults: &adults, Children: &children, Infants: &infants, Pets: &pets}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		guests *domain.Guests
+		price  float32
+		err    error
+	}{
+		{"one adult by default", nil, 110, nil},
+		{"included guests", guests(2, 0, 0, 0), 110, nil},
+		{"extra guests", guests(2, 2, 1, 0), 170, nil},
+		{"a pet", guests(1, 0, 0, 1), 130, nil},
+		{"infants aside", guests(2, 2, 2, 0), 170, nil},
+		{"over capacity", guests(3, 2, 0, 0), 0, domain.ErrCapacityExceeded},
+		{"too many pets", guests(1, 0, 0, 2), 0, domain.ErrPetsNotAllowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bookingsStore := memory.NewBookingsStore()
+			srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+			srv.now = func() time.Time { return start }
+
+			confirmation, err := srv.BookProperty(context.Background(), domain.BookingRequest{
+				PropertyId: 1,
+				StartDate:  openapi_types.Date{Time: start},
+				EndDate:    openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+				Guests:     tc.guests,
+			})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("BookProperty() error = %v, want %v", err, tc.err)
+			}
+			if err == nil && confirmation.TotalAmount != tc.price {
+				t.Errorf("BookProperty() total = %v, want %v", confirmation.TotalAmount, tc.price)
+			}
+		})
+	}
+}
+
+func TestBookPropertyRoomTypes(t *testing.T) {
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, RoomTypes: &[]domain.RoomType{
+		{RoomTypeId: "double", Name: "Double room", Units: 2, Guests: 2, Size: 30},
+		{RoomTypeId: "suite", Name: "Suite", Units: 1, Guests: 4, Size: 60},
+	}}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	start := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return start }
+	book := func(roomTypeId *string, adults int) (domain.BookingResponse, error) {
+		return srv.BookProperty(context.Background(), domain.BookingRequest{
+			PropertyId: 1,
+			StartDate:  openapi_types.Date{Time: start},
+			EndDate:    openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+			Guests:     &domain.Guests{Adults: &adults},
+			RoomTypeId: roomTypeId,
+		})
+	}
+	double, loft := "double", "loft"
+
+	if _, err := book(&loft, 1); !errors.Is(err, domain.ErrRoomTypeNotFound) {
+		t.Errorf("BookProperty(loft) error = %v, want %v", err, domain.ErrRoomTypeNotFound)
+	}
+	if _, err := book(&double, 3); !errors.Is(err, domain.ErrCapacityExceeded) {
+		t.Errorf("BookProperty(double, 3 adults) error = %v, want %v", err, domain.ErrCapacityExceeded)
+	}
+	for _, want := range []struct {
+		roomTypeId string
+		unit       int
+		price      float32
+	}{
+		{"double", 1, 60},
+		{"double", 2, 60},
+		{"suite", 1, 120},
+	} {
+		confirmation, err := book(nil, 2)
+		if err != nil {
+			t.Fatalf("BookProperty() error = %v", err)
+		}
+		if confirmation.RoomTypeId == nil || *confirmation.RoomTypeId != want.roomTypeId ||
+			confirmation.Unit == nil || *confirmation.Unit != want.unit || confirmation.TotalAmount != want.price {
+			t.Errorf("BookProperty() = %+v, want unit %d of %s for %v",
+				confirmation, want.unit, want.roomTypeId, want.price)
+		}
+	}
+	if _, err := book(nil, 2); !errors.Is(err, domain.ErrPropertyNotAvailable) {
+		t.Errorf("BookProperty() error = %v, want %v", err, domain.ErrPropertyNotAvailable)
+	}
+}
+
+func TestGetAvailabilityRoomTypes(t *testing.T) {
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, RoomTypes: &[]domain.RoomType{
+		{RoomTypeId: "double", Name: "Double room", Units: 2, Guests: 2, Size: 30},
+		{RoomTypeId: "suite", Name: "Suite", Units: 1, Guests: 4, Size: 60},
+	}}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	start := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return start }
+	end := start.AddDate(0, 0, 2)
+	double, suite := "double", "suite"
+	for unit := 1; unit <= 2; unit++ {
+		err := bookingsStore.AddBooking(context.Background(), domain.Booking{
+			BookingId: uuid.NewString(),
+			BookingRequest: domain.BookingRequest{
+				PropertyId: 1,
+				StartDate:  openapi_types.Date{Time: start},
+				EndDate:    openapi_types.Date{Time: end},
+				RoomTypeId: &double,
+			},
+			Unit: &unit,
+		})
+		if err != nil {
+			t.Fatalf("AddBooking() error = %v", err)
+		}
+	}
+
+	availability, err := srv.GetAvailability(context.Background(), 1, start, end, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("GetAvailability() error = %v", err)
+	}
+	if !availability.Available || availability.Price != 120 || availability.RoomTypes == nil ||
+		len(*availability.RoomTypes) != 2 {
+		t.Fatalf("GetAvailability() = %+v, want the suite available for 120", availability)
+	}
+	for i, want := range []domain.RoomTypeAvailability{
+		{RoomTypeId: "double", Name: "Double room", Available: false, UnitsAvailable: 0, Price: 60},
+		{RoomTypeId: "suite", Name: "Suite", Available: true, UnitsAvailable: 1, Price: 120},
+	} {
+		if got := (*availability.RoomTypes)[i]; got != want {
+			t.Errorf("GetAvailability().RoomTypes[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	availability, err = srv.GetAvailability(context.Background(), 1, start, end, nil, &double, nil, nil)
+	if err != nil || availability.Available {
+		t.Errorf("GetAvailability(double) = %+v, %v, want it unavailable", availability, err)
+	}
+	available, err := srv.IsAvailable(context.Background(), property, start, end)
+	if err != nil || !available {
+		t.Errorf("IsAvailable() = %v, %v, want the suite free", available, err)
+	}
+	availability, err = srv.GetAvailability(context.Background(), 1, start, end, nil, &suite, nil, nil)
+	if err != nil || !availability.Available || availability.Price != 120 {
+		t.Errorf("GetAvailability(suite) = %+v, %v, want it available for 120", availability, err)
+	}
+}
+
+func TestBookPropertyRatePlans(t *testing.T) {
+	zero, two := 0, 2
+	var discount, breakfast float32 = -10, 12
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, RoomTypes: &[]domain.RoomType{
+		{RoomTypeId: "double", Name: "Double room", Units: 2, Guests: 2, Size: 30},
+		{RoomTypeId: "suite", Name: "Suite", Units: 1, Guests: 4, Size: 60},
+	}, RatePlans: &[]domain.RatePlan{
+		{RatePlanId: "non-refundable", Name: "Non-refundable", PriceAdjustmentPercent: &discount,
+			CancellationPolicy: domain.CancellationPolicy{Refundable: false}},
+		{RatePlanId: "breakfast", Name: "Breakfast included", GuestNightFee: &breakfast,
+			RoomTypeIds:        &[]string{"suite"},
+			CancellationPolicy: domain.CancellationPolicy{Refundable: true, DeadlineDays: &two}},
+		{RatePlanId: "flexible", Name: "Flexible",
+			CancellationPolicy: domain.CancellationPolicy{Refundable: true, DeadlineDays: &zero}},
+	}}
+	start := time.Date(2024, time.July, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+	until := func(days int) *time.Time {
+		deadline := time.Date(2024, time.July, 10-days, 15, 0, 0, 0, time.UTC)
+		return &deadline
+	}
+	double := "double"
+
+	for _, tc := range []struct {
+		name         string
+		ratePlanId   string
+		roomTypeId   *string
+		price        float32
+		roomType     string
+		cancellation *domain.CancellationTerms
+		err          error
+	}{
+		{"standard rate", "", nil, 60, "double", nil, nil},
+		{"price adjustment", "non-refundable", nil, 54, "double", &domain.CancellationTerms{}, nil},
+		{"guest fee for the offered room types", "breakfast", nil, 144, "suite",
+			&domain.CancellationTerms{Refundable: true, CancellableUntil: until(2)}, nil},
+		{"cancellation until the check-in", "flexible", nil, 60, "double",
+			&domain.CancellationTerms{Refundable: true, CancellableUntil: until(0)}, nil},
+		{"room type not offered", "breakfast", &double, 0, "", nil, domain.ErrRatePlanNotFound},
+		{"unknown rate plan", "member", nil, 0, "", nil, domain.ErrRatePlanNotFound},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bookingsStore := memory.NewBookingsStore()
+			srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+			srv.now = func() time.Time { return now }
+			request := domain.BookingRequest{
+				PropertyId: 1,
+				StartDate:  openapi_types.Date{Time: start},
+				EndDate:    openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+				RoomTypeId: tc.roomTypeId,
+			}
+			if tc.ratePlanId != "" {
+				request.RatePlanId = &tc.ratePlanId
+			}
+
+			confirmation, err := srv.BookProperty(context.Background(), request)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("BookProperty() error = %v, want %v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+			if confirmation.TotalAmount != tc.price || *confirmation.RoomTypeId != tc.roomType {
+				t.Errorf("BookProperty() = %v for %s, want %v for %s",
+					confirmation.TotalAmount, *confirmation.RoomTypeId, tc.price, tc.roomType)
+			}
+			if !sameTerms(confirmation.Cancellation, tc.cancellation) {
+				t.Errorf("BookProperty().Cancellation = %+v, want %+v", confirmation.Cancellation, tc.cancellation)
+			}
+		})
+	}
+}
+
+func TestGetAvailabilityRatePlans(t *testing.T) {
+	var discount, breakfast float32 = -10, 12
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, RatePlans: &[]domain.RatePlan{
+		{RatePlanId: "non-refundable", Name: "Non-refundable", PriceAdjustmentPercent: &discount},
+		{RatePlanId: "breakfast", Name: "Breakfast included", GuestNightFee: &breakfast,
+			CancellationPolicy: domain.CancellationPolicy{Refundable: true}},
+	}}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	start := time.Date(2024, time.July, 10, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return start.AddDate(0, 0, -1) }
+	adults := 2
+	guests := &domain.Guests{Adults: &adults}
+
+	availability, err := srv.GetAvailability(context.Background(), 1, start, start.AddDate(0, 0, 2), guests, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("GetAvailability() error = %v", err)
+	}
+	if availability.Price != 110 || availability.RatePlans == nil || len(*availability.RatePlans) != 2 ||
+		(*availability.RatePlans)[0].Price != 99 || (*availability.RatePlans)[1].Price != 158 ||
+		!(*availability.RatePlans)[1].Cancellation.Refundable {
+		t.Errorf("GetAvailability() = %+v, want 110 with the rate plans for 99 and 158", availability)
+	}
+
+	breakfastId := "breakfast"
+	availability, err = srv.GetAvailability(context.Background(), 1, start, start.AddDate(0, 0, 2), guests,
+		nil, &breakfastId, nil)
+	if err != nil || availability.Price != 158 || len(*availability.RatePlans) != 1 {
+		t.Errorf("GetAvailability(breakfast) = %+v, %v, want it alone for 158", availability, err)
+	}
+}
+
+func TestCancelRatePlans(t *testing.T) {
+	two := 2
+	property := domain.Property{PropertyId: 1, Size: 55, Guests: 4, RatePlans: &[]domain.RatePlan{
+		{RatePlanId: "non-refundable", Name: "Non-refundable"},
+		{RatePlanId: "flexible", Name: "Flexible",
+			CancellationPolicy: domain.CancellationPolicy{Refundable: true, DeadlineDays: &two}},
+	}}
+	start := time.Date(2024, time.July, 10, 0, 0, 0, 0, time.UTC)
+	booked := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name       string
+		ratePlanId string
+		now        time.Time
+		err        error
+	}{
+		{"standard rate", "", start, nil},
+		{"before the deadline", "flexible", time.Date(2024, time.July, 8, 14, 59, 0, 0, time.UTC), nil},
+		{"past the deadline", "flexible", time.Date(2024, time.July, 8, 15, 0, 0, 0, time.UTC), domain.ErrNotCancellable},
+		{"non-refundable", "non-refundable", booked, domain.ErrNotCancellable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bookingsStore := memory.NewBookingsStore()
+			srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+			srv.now = func() time.Time { return booked }
+			request := domain.BookingRequest{
+				PropertyId: 1,
+				StartDate:  openapi_types.Date{Time: start},
+				EndDate:    openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+			}
+			if tc.ratePlanId != "" {
+				request.RatePlanId = &tc.ratePlanId
+			}
+			confirmation, err := srv.BookProperty(context.Background(), request)
+			if err != nil {
+				t.Fatalf("BookProperty() error = %v", err)
+			}
+
+			srv.now = func() time.Time { return tc.now }
+			if err := srv.Cancel(context.Background(), confirmation.BookingId); !errors.Is(err, tc.err) {
+				t.Errorf("Cancel() error = %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestBookPropertyPromoCodes(t *testing.T) {
+	property := domain.Property{PropertyId: 1, City: "Krakow", Size: 50, Guests: 4}
+	start := time.Date(2024, time.July, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+	percent := func(value float32) *float32 { return &value }
+	at := func(t time.Time) *time.Time { return &t }
+	one, three := 1, 3
+	promotions := []domain.Promotion{
+		{Code: "TENOFF", DiscountPercent: percent(10)},
+		{Code: "FIFTY", DiscountAmount: percent(50), Cities: &[]string{"krakow"}},
+		{Code: "ALL", DiscountAmount: percent(500), PropertyIds: &[]int{1}},
+		{Code: "LONG", DiscountPercent: percent(10), MinNights: &three},
+		{Code: "EXPIRED", DiscountPercent: percent(10), ValidUntil: at(now)},
+		{Code: "LATER", DiscountPercent: percent(10), ValidFrom: at(now.AddDate(0, 0, 1))},
+		{Code: "GDANSK", DiscountPercent: percent(10), Cities: &[]string{"Gdansk"}, PropertyIds: &[]int{2}},
+		{Code: "ONCE", DiscountPercent: percent(10), MaxRedemptions: &one, Redemptions: 1},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		promoCode string
+		price     float32
+		discount  float32
+		err       error
+	}{
+		{"percent", " tenoff", 90, 10, nil},
+		{"amount in the city", "FIFTY", 50, 50, nil},
+		{"amount over the price", "ALL", 0, 100, nil},
+		{"stay too short", "LONG", 0, 0, domain.ErrInvalidPromoCode},
+		{"expired", "EXPIRED", 0, 0, domain.ErrInvalidPromoCode},
+		{"not valid yet", "LATER", 0, 0, domain.ErrInvalidPromoCode},
+		{"other properties", "GDANSK", 0, 0, domain.ErrInvalidPromoCode},
+		{"used up", "ONCE", 0, 0, domain.ErrInvalidPromoCode},
+		{"unknown", "NOPE", 0, 0, domain.ErrInvalidPromoCode},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bookingsStore := memory.NewBookingsStore()
+			for _, promotion := range promotions {
+				if err := bookingsStore.AddPromotion(context.Background(), promotion); err != nil {
+					t.Fatalf("AddPromotion() error = %v", err)
+				}
+			}
+			srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+			srv.now = func() time.Time { return now }
+
+			confirmation, err := srv.BookProperty(context.Background(), domain.BookingRequest{
+				PropertyId: 1,
+				StartDate:  openapi_types.Date{Time: start},
+				EndDate:    openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+				PromoCode:  &tc.promoCode,
+			})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("BookProperty() error = %v, want %v", err, tc.err)
+			}
+			if err != nil {
+				return
+			}
+			if confirmation.TotalAmount != tc.price || *confirmation.Discount != tc.discount {
+				t.Errorf("BookProperty() = %v with %v off, want %v with %v off",
+					confirmation.TotalAmount, *confirmation.Discount, tc.price, tc.discount)
+			}
+			code := *confirmation.PromoCode
+			promotion, err := bookingsStore.GetPromotion(context.Background(), code)
+			if err != nil || promotion.Redemptions != 1 {
+				t.Errorf("GetPromotion(%s) = %+v, %v, want it redeemed once", code, promotion, err)
+			}
+		})
+	}
+}
+
+func TestGetAvailabilityPromoCode(t *testing.T) {
+	var breakfast, percent float32 = 12, 25
+	property := domain.Property{PropertyId: 1, Size: 50, Guests: 4,
+		RoomTypes: &[]domain.RoomType{
+			{RoomTypeId: "double", Name: "Double room", Units: 1, Guests: 2, Size: 30},
+			{RoomTypeId: "suite", Name: "Suite", Units: 1, Guests: 4, Size: 60},
+		},
+		RatePlans: &[]domain.RatePlan{{RatePlanId: "breakfast", Name: "Breakfast included", GuestNightFee: &breakfast}},
+	}
+	bookingsStore := memory.NewBookingsStore()
+	if err := bookingsStore.AddPromotion(context.Background(),
+		domain.Promotion{Code: "QUARTER", DiscountPercent: &percent}); err != nil {
+		t.Fatalf("AddPromotion() error = %v", err)
+	}
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	start := time.Date(2024, time.July, 10, 0, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return start.AddDate(0, 0, -1) }
+
+	code := "quarter"
+	availability, err := srv.GetAvailability(context.Background(), 1, start, start.AddDate(0, 0, 2), nil,
+		nil, nil, &code)
+	if err != nil {
+		t.Fatalf("GetAvailability() error = %v", err)
+	}
+	if availability.Price != 45 || *availability.Discount != 15 || (*availability.RoomTypes)[1].Price != 90 ||
+		(*availability.RatePlans)[0].Price != 63 {
+		t.Errorf("GetAvailability() = %+v, want 45 with 15 off, the suite for 90 and breakfast for 63", availability)
+	}
+
+	code = "HALF"
+	_, err = srv.GetAvailability(context.Background(), 1, start, start.AddDate(0, 0, 2), nil, nil, nil, &code)
+	if !errors.Is(err, domain.ErrInvalidPromoCode) {
+		t.Errorf("GetAvailability(HALF) error = %v, want %v", err, domain.ErrInvalidPromoCode)
+	}
+	// checking the availability redeems nothing
+	if promotion, err := bookingsStore.GetPromotion(context.Background(), "QUARTER"); err != nil ||
+		promotion.Redemptions != 0 {
+		t.Errorf("GetPromotion() = %+v, %v, want no redemptions", promotion, err)
+	}
+}
+
+func TestGetAvailabilityDynamicPricing(t *testing.T) {
+	now := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) openapi_types.Date {
+		return openapi_types.Date{Time: time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+	bounds := &domain.DynamicPricing{FloorPercent: 80, CeilingPercent: 150}
+	email := "john.doe@example.com"
+
+	for _, tc := range []struct {
+		name    string
+		pricing *domain.DynamicPricing
+		busy    bool
+		start   int
+		price   float32
+	}{
+		{"static rates", nil, true, 15, 200},
+		{"busy property", bounds, true, 15, 215},
+		{"weekend nights", bounds, true, 19, 247.25},
+		{"last minute nights", bounds, true, 2, 193.5},
+		{"ceiling", &domain.DynamicPricing{FloorPercent: 80, CeilingPercent: 120}, true, 19, 240},
+		{"floor", bounds, false, 15, 160},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			property := domain.Property{PropertyId: 1, City: "Krakow", Size: 100, Guests: 4,
+				DynamicPricing: tc.pricing}
+			bookingsStore := memory.NewBookingsStore()
+			propertiesStore := memory.NewPropertiesStore(property,
+				domain.Property{PropertyId: 2, City: "Krakow", Size: 60, Guests: 2})
+			srv := NewService(bookingsStore, bookingsStore, bookingsStore, propertiesStore)
+			srv.now = func() time.Time { return now }
+			if tc.busy {
+				// the recent nights of the property are all booked, the ones of the city half of them
+				err := bookingsStore.AddBooking(context.Background(), domain.Booking{
+					BookingId: uuid.NewString(),
+					BookingRequest: domain.BookingRequest{PropertyId: 1, CustomerName: "Jane Doe",
+						StartDate: date(time.June, 3), EndDate: date(time.July, 1),
+						ContactDetails: domain.ContactDetails{Email: &email}},
+				})
+				if err != nil {
+					t.Fatalf("AddBooking() error = %v", err)
+				}
+			}
+
+			start, end := date(time.July, tc.start), date(time.July, tc.start+2)
+			availability, err := srv.GetAvailability(context.Background(), 1, start.Time, end.Time,
+				nil, nil, nil, nil)
+			if err != nil || availability.Price != tc.price {
+				t.Fatalf("GetAvailability() = %+v, %v, want %v", availability, err, tc.price)
+			}
+			confirmation, err := srv.BookProperty(context.Background(), domain.BookingRequest{
+				PropertyId: 1, StartDate: start, EndDate: end})
+			if err != nil || confirmation.TotalAmount != tc.price {
+				t.Errorf("BookProperty() = %v, %v, want %v", confirmation.TotalAmount, err, tc.price)
+			}
+		})
+	}
+}
+
+func TestNightlyRatesCached(t *testing.T) {
+	now := time.Date(2024, time.July, 1, 12, 0, 0, 0, time.UTC)
+	property := domain.Property{PropertyId: 1, City: "Krakow", Size: 100, Guests: 4,
+		DynamicPricing: &domain.DynamicPricing{FloorPercent: 80, CeilingPercent: 150}}
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(bookingsStore, bookingsStore, bookingsStore, memory.NewPropertiesStore(property))
+	srv.now = func() time.Time { return now }
+	schedule, err := newSchedule(property)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	occupancy := func() float64 {
+		t.Helper()
+		rates, err := srv.nightlyRates(context.Background(), property, schedule)
+		if err != nil {
+			t.Fatalf("nightlyRates() error = %v", err)
+		}
+		return rates.occupancy
+	}
+	if got := occupancy(); got != 0 {
+		t.Fatalf("nightlyRates() occupancy = %v, want 0", got)
+	}
+
+	email := "john.doe@example.com"
+	err = bookingsStore.AddBooking(context.Background(), domain.Booking{
+		BookingId: uuid.NewString(),
+		BookingRequest: domain.BookingRequest{PropertyId: 1, CustomerName: "Jane Doe",
+			StartDate:      openapi_types.Date{Time: now.AddDate(0, 0, -28).Truncate(24 * time.Hour)},
+			EndDate:        openapi_types.Date{Time: now.Truncate(24 * time.Hour)},
+			ContactDetails: domain.ContactDetails{Email: &email}},
+	})
+	if err != nil {
+		t.Fatalf("AddBooking() error = %v", err)
+	}
+	if got := occupancy(); got != 0 {
+		t.Errorf("nightlyRates() occupancy = %v, want the cached 0", got)
+	}
+	now = now.Add(occupancyTTL)
+	if got := occupancy(); got != 1 {
+		t.Errorf("nightlyRates() occupancy = %v, want 1 measured again", got)
+	}
+}
+
+func sameTerms(got, want *domain.CancellationTerms) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	if got.CancellableUntil == nil || want.CancellableUntil == nil {
+		return got.Refundable == want.Refundable && got.CancellableUntil == want.CancellableUntil
+	}
+	return got.Refundable == want.Refundable && got.CancellableUntil.Equal(*want.CancellableUntil)
+}
@@ -0,0 +1,498 @@
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stay is a resolved stay, from the night of start up to the morning of end,
+// with the notes explaining how the expression was read.
+type stay struct {
+	start time.Time
+	end   time.Time
+	notes []string
+}
+
+// point is a date an expression names, or a period for the weeks and the
+// weekends, which end is set then.
+type point struct {
+	start time.Time
+	end   time.Time
+	note  string
+}
+
+// unresolvedError tells what part of the expression could not be read.
+type unresolvedError struct {
+	message string
+}
+
+func (err *unresolvedError) Error() string {
+	return err.message
+}
+
+func unresolved(format string, args ...any) error {
+	return &unresolvedError{message: fmt.Sprintf(format, args...)}
+}
+
+var tokenPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}|\d+(?:st|nd|rd|th)?|[a-z]+|-`)
+
+// fillers carry no meaning for the dates, e.g. "the second week of July".
+var fillers = map[string]bool{"the": true, "on": true, "of": true, "at": true, "starting": true,
+	"arriving": true, "please": true}
+
+var separators = map[string]bool{"to": true, "until": true, "till": true, "through": true,
+	"thru": true, "-": true, "and": true}
+
+var numbers = map[string]int{"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13,
+	"fourteen": 14, "fifteen": 15, "twenty": 20, "thirty": 30, "couple": 2}
+
+var ordinals = map[string]int{"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1}
+
+// nightsPerUnit counts the days as nights, as a stay of 3 days is booked
+// for 3 nights.
+var nightsPerUnit = map[string]int{"night": 1, "nights": 1, "day": 1, "days": 1, "week": 7, "weeks": 7,
+	"fortnight": 14}
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+// parse resolves the expression against the reference date, a midnight in
+// UTC like all the dates of the API. The expression names the start of the
+// stay and either its end, its length or a period, e.g. "next Friday for
+// three nights", "July 12-15" or "the second week of July".
+func parse(expression string, reference time.Time) (stay, error) {
+	tokens := tokenize(expression)
+	if len(tokens) == 0 {
+		return stay{}, unresolved("the expression is empty")
+	}
+
+	tokens, nights, hasNights := takeLength(tokens)
+	left, right := splitRange(tokens)
+	left = shareMonth(left, right)
+
+	start, err := parsePoint(left, reference, nil)
+	if err != nil {
+		return stay{}, err
+	}
+	result := stay{start: start.start, notes: []string{start.note}}
+
+	switch {
+	case right != nil && hasNights:
+		return stay{}, unresolved("the expression has both an end date and a length of stay")
+	case right != nil:
+		end, err := parsePoint(right, reference, &start.start)
+		if err != nil {
+			return stay{}, err
+		}
+		result.end = end.start
+		result.notes = append(result.notes, end.note)
+	case hasNights:
+		result.end = result.start.AddDate(0, 0, nights)
+		result.notes = append(result.notes, fmt.Sprintf("The stay lasts %d %s, until %s.",
+			nights, pluralNights(nights), describe(result.end)))
+	case !start.end.IsZero():
+		result.end = start.end
+	default:
+		result.end = result.start.AddDate(0, 0, 1)
+		result.notes = append(result.notes, "No end or length of stay is given, one night is assumed.")
+	}
+
+	if !result.end.After(result.start) {
+		return stay{}, unresolved("the stay ends on %s, before it starts on %s",
+			result.end.Format(time.DateOnly), result.start.Format(time.DateOnly))
+	}
+	return result, nil
+}
+
+func tokenize(expression string) []string {
+	expression = strings.NewReplacer("–", "-", "—", "-").Replace(strings.ToLower(expression))
+	var tokens []string
+	for _, token := range tokenPattern.FindAllString(expression, -1) {
+		if !fillers[token] {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// takeLength removes the length of the stay from the tokens, e.g. "for
+// three nights". The same words naming the start, as in "in 3 days" or
+// "2 weeks from now", are left in place.
+func takeLength(tokens []string) ([]string, int, bool) {
+	for i := range tokens {
+		n, width, ok := readNumber(tokens, i)
+		if !ok || i+width >= len(tokens) {
+			continue
+		}
+		unit, ok := nightsPerUnit[tokens[i+width]]
+		if !ok {
+			continue
+		}
+		if i > 0 && tokens[i-1] == "in" {
+			continue
+		}
+		if next := i + width + 1; next < len(tokens) &&
+			(tokens[next] == "from" || tokens[next] == "later" || tokens[next] == "after") {
+			continue
+		}
+
+		from := i
+		if from > 0 && tokens[from-1] == "for" {
+			from--
+		}
+		rest := append(append([]string{}, tokens[:from]...), tokens[i+width+1:]...)
+		return rest, n * unit, true
+	}
+	return tokens, 0, false
+}
+
+// readNumber reads a number written in digits or words at i, returning the
+// count of the tokens it spans.
+func readNumber(tokens []string, i int) (int, int, bool) {
+	if tokens[i] == "a" && i+1 < len(tokens) && tokens[i+1] == "couple" {
+		return 2, 2, true
+	}
+	if n, ok := numbers[tokens[i]]; ok {
+		return n, 1, true
+	}
+	if n, err := strconv.Atoi(tokens[i]); err == nil {
+		return n, 1, true
+	}
+	return 0, 0, false
+}
+
+// splitRange splits the tokens on the first separator of the end date, e.g.
+// "from July 12 to July 15" or "between Monday and Thursday".
+func splitRange(tokens []string) ([]string, []string) {
+	if len(tokens) > 0 && (tokens[0] == "from" || tokens[0] == "between") {
+		tokens = tokens[1:]
+	}
+	for i, token := range tokens {
+		if separators[token] && i > 0 && i < len(tokens)-1 {
+			return tokens[:i], tokens[i+1:]
+		}
+	}
+	return tokens, nil
+}
+
+// shareMonth gives a day alone at the start of a range the month, and the
+// year, of its end, as in "12th to 15th of July".
+func shareMonth(left, right []string) []string {
+	if len(left) != 1 || len(right) < 2 {
+		return left
+	}
+	if _, ok := readDay(left[0]); !ok {
+		return left
+	}
+	if _, ok := readDay(right[0]); !ok {
+		return left
+	}
+	if _, ok := months[right[1]]; !ok {
+		return left
+	}
+	return append([]string{left[0]}, right[1:]...)
+}
+
+// parsePoint reads a date or a period. The end of a range is read after its
+// start, so that "15" in "July 12-15" and a date without a year fall after it.
+func parsePoint(tokens []string, reference time.Time, after *time.Time) (point, error) {
+	phrase := strings.Join(tokens, " ")
+	if len(tokens) == 0 {
+		return point{}, unresolved("a date is missing")
+	}
+	at := func(date time.Time, reason string) (point, error) {
+		return point{start: date, note: note(phrase, date, reason)}, nil
+	}
+
+	if len(tokens) == 1 {
+		if date, err := time.Parse(time.DateOnly, tokens[0]); err == nil {
+			return at(date, "")
+		}
+	}
+
+	switch phrase {
+	case "today", "tonight", "now":
+		return at(reference, "")
+	case "tomorrow", "tomorrow night":
+		return at(reference.AddDate(0, 0, 1), "")
+	case "day after tomorrow":
+		return at(reference.AddDate(0, 0, 2), "")
+	}
+
+	// in 3 days, 2 weeks from now
+	if tokens[0] == "in" && len(tokens) == 3 || len(tokens) >= 3 &&
+		(tokens[2] == "from" || tokens[2] == "later" || tokens[2] == "after") {
+		offset := tokens
+		if offset[0] == "in" {
+			offset = offset[1:]
+		}
+		if n, _, ok := readNumber(offset, 0); ok {
+			if unit, ok := nightsPerUnit[offset[1]]; ok {
+				return at(reference.AddDate(0, 0, n*unit), "")
+			}
+		}
+	}
+
+	qualifier := ""
+	rest := tokens
+	if tokens[0] == "this" || tokens[0] == "next" || tokens[0] == "coming" {
+		qualifier, rest = tokens[0], tokens[1:]
+	}
+
+	if len(rest) == 1 {
+		if weekday, ok := weekdays[rest[0]]; ok {
+			if after != nil {
+				// the end of a range, as in "from Monday to Thursday"
+				return parseWeekday(phrase, "next", weekday, *after)
+			}
+			return parseWeekday(phrase, qualifier, weekday, reference)
+		}
+		switch rest[0] {
+		case "weekend":
+			return parseWeekend(phrase, qualifier, reference), nil
+		case "week":
+			if qualifier == "next" {
+				monday := startOfWeek(reference).AddDate(0, 0, 7)
+				return point{start: monday, end: monday.AddDate(0, 0, 7),
+					note: note(phrase, monday, "the week starting on the Monday after this one")}, nil
+			}
+		}
+	}
+
+	// the second week of July, the last weekend of August
+	if n, ok := readOrdinal(tokens[0]); ok && len(tokens) >= 2 &&
+		(tokens[1] == "week" || tokens[1] == "weekend") {
+		return parseWeekOfMonth(phrase, n, tokens[1] == "weekend", tokens[2:], reference)
+	}
+
+	// a weekday naming the day of a date is only checked against it
+	if weekday, ok := weekdays[tokens[0]]; ok && len(tokens) > 1 && qualifier == "" {
+		date, err := parsePoint(tokens[1:], reference, after)
+		if err == nil && date.start.Weekday() != weekday {
+			date.note += fmt.Sprintf(" It is a %s, not a %s.", date.start.Weekday(), weekday)
+		}
+		return date, err
+	}
+
+	return parseDate(phrase, tokens, reference, after)
+}
+
+// parseWeekday takes a weekday alone or "this" one as the first one from the
+// given date on and "next" one as the first one after it.
+func parseWeekday(phrase, qualifier string, weekday time.Weekday, from time.Time) (point, error) {
+	days := (int(weekday) - int(from.Weekday()) + 7) % 7
+	reason := "the first " + weekday.String() + " from " + describe(from) + " on"
+	if qualifier == "next" {
+		if days == 0 {
+			days = 7
+		}
+		reason = "the first " + weekday.String() + " after " + describe(from)
+	}
+	date := from.AddDate(0, 0, days)
+	return point{start: date, note: note(phrase, date, reason)}, nil
+}
+
+// parseWeekend takes a weekend as the nights of Friday and Saturday. This
+// weekend is the one in progress or the coming one, the next weekend the one
+// after it.
+func parseWeekend(phrase, qualifier string, reference time.Time) point {
+	friday := startOfWeek(reference).AddDate(0, 0, 4)
+	if reference.Weekday() == time.Sunday {
+		// the last night of the weekend is over
+		friday = friday.AddDate(0, 0, 7)
+	}
+	reason := "the nights of Friday and Saturday of this weekend"
+	if qualifier == "next" {
+		friday = friday.AddDate(0, 0, 7)
+		reason = "the nights of Friday and Saturday of the weekend after this one"
+	}
+	start := friday
+	if reference.After(start) {
+		// on a Saturday the weekend is half gone
+		start = reference
+	}
+	return point{start: start, end: friday.AddDate(0, 0, 2), note: note(phrase, start, reason)}
+}
+
+// parseWeekOfMonth takes the n-th week of a month as the one starting on its
+// n-th Monday and the n-th weekend as the one starting on its n-th Friday.
+func parseWeekOfMonth(phrase string, n int, weekend bool, tokens []string, reference time.Time) (point, error) {
+	if len(tokens) > 0 && tokens[0] == "in" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return point{}, unresolved("the month of %q is missing", phrase)
+	}
+	month, ok := months[tokens[0]]
+	if !ok {
+		return point{}, unresolved("%q is not a month", tokens[0])
+	}
+	year, err := readYear(tokens[1:], reference.Year())
+	if err != nil {
+		return point{}, err
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if len(tokens) == 1 && first.AddDate(0, 1, 0).Before(reference) {
+		first = first.AddDate(1, 0, 0)
+	}
+
+	weekday, nights, what := time.Monday, 7, "week"
+	if weekend {
+		weekday, nights, what = time.Friday, 2, "weekend"
+	}
+	start := first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7)
+	if n < 0 {
+		for start.AddDate(0, 0, 7).Month() == month {
+			start = start.AddDate(0, 0, 7)
+		}
+	} else {
+		start = start.AddDate(0, 0, 7*(n-1))
+		if start.Month() != month {
+			return point{}, unresolved("%s %s has no %s %s", month, strconv.Itoa(first.Year()), ordinal(n), what)
+		}
+	}
+	reason := fmt.Sprintf("the %s starting on the %s %s of %s", what, ordinal(n), weekday, month)
+	return point{start: start, end: start.AddDate(0, 0, nights), note: note(phrase, start, reason)}, nil
+}
+
+// parseDate reads a day of a month, as "July 12", "12th July" or "12 July
+// 2025", or a day alone after the start of a range, as in "July 12-15". A date
+// without a year is the next one from the reference date, or the start, on.
+func parseDate(phrase string, tokens []string, reference time.Time, after *time.Time) (point, error) {
+	from := reference
+	if after != nil {
+		from = *after
+	}
+
+	var day int
+	var month time.Month
+	var rest []string
+	if m, ok := months[tokens[0]]; ok && len(tokens) >= 2 {
+		month, rest = m, tokens[2:]
+		day, ok = readDay(tokens[1])
+		if !ok {
+			return point{}, unresolved("%q is not a date", phrase)
+		}
+	} else if d, ok := readDay(tokens[0]); ok {
+		day = d
+		if len(tokens) == 1 && after != nil {
+			// the end of a range in the month of its start
+			date := time.Date(from.Year(), from.Month(), day, 0, 0, 0, 0, time.UTC)
+			if !date.After(from) {
+				date = date.AddDate(0, 1, 0)
+			}
+			return point{start: date, note: note(phrase, date, "")}, nil
+		}
+		if len(tokens) < 2 {
+			return point{}, unresolved("%q is not a date", phrase)
+		}
+		if month, ok = months[tokens[1]]; !ok {
+			return point{}, unresolved("%q is not a date", phrase)
+		}
+		rest = tokens[2:]
+	} else {
+		return point{}, unresolved("%q is not a date", phrase)
+	}
+
+	year, err := readYear(rest, from.Year())
+	if err != nil {
+		return point{}, err
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return point{}, unresolved("%s has no day %d", month, day)
+	}
+	reason := ""
+	if len(rest) == 0 && date.Before(from) {
+		date = date.AddDate(1, 0, 0)
+		reason = "the next one"
+	}
+	return point{start: date, note: note(phrase, date, reason)}, nil
+}
+
+func readDay(token string) (int, bool) {
+	day, err := strconv.Atoi(strings.TrimRight(token, "stndrh"))
+	return day, err == nil && day >= 1 && day <= 31
+}
+
+func readOrdinal(token string) (int, bool) {
+	if n, ok := ordinals[token]; ok {
+		return n, true
+	}
+	if strings.TrimLeft(token, "0123456789") == "" {
+		return 0, false
+	}
+	n, ok := readDay(token)
+	return n, ok && n <= 5
+}
+
+func readYear(tokens []string, defaultYear int) (int, error) {
+	switch len(tokens) {
+	case 0:
+		return defaultYear, nil
+	case 1:
+		if year, err := strconv.Atoi(tokens[0]); err == nil && len(tokens[0]) == 4 {
+			return year, nil
+		}
+	}
+	return 0, unresolved("%q is not a year", strings.Join(tokens, " "))
+}
+
+// startOfWeek returns the Monday of the week of the date.
+func startOfWeek(date time.Time) time.Time {
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
+
+func note(phrase string, date time.Time, reason string) string {
+	if reason != "" {
+		return fmt.Sprintf("%q is %s, %s.", phrase, describe(date), reason)
+	}
+	return fmt.Sprintf("%q is %s.", phrase, describe(date))
+}
+
+// describe writes the date with its weekday, e.g. Friday 2024-07-05.
+func describe(date time.Time) string {
+	return date.Weekday().String() + " " + date.Format(time.DateOnly)
+}
+
+func ordinal(n int) string {
+	for word, value := range ordinals {
+		if value == n {
+			return word
+		}
+	}
+	return strconv.Itoa(n) + "th"
+}
+
+func pluralNights(n int) string {
+	if n == 1 {
+		return "night"
+	}
+	return "nights"
+}
@@ -0,0 +1,72 @@
+package dates
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// a Wednesday
+	reference := time.Date(2024, time.July, 3, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		expression string
+		start, end string
+	}{
+		{"today", "2024-07-03", "2024-07-04"},
+		{"tomorrow for 2 nights", "2024-07-04", "2024-07-06"},
+		{"next Friday for three nights", "2024-07-05", "2024-07-08"},
+		{"Wednesday for a week", "2024-07-03", "2024-07-10"},
+		{"next Wednesday for a couple of nights", "2024-07-10", "2024-07-12"},
+		{"from Monday to Thursday", "2024-07-08", "2024-07-11"},
+		{"in 3 days for 2 nights", "2024-07-06", "2024-07-08"},
+		{"2 weeks from now for 4 days", "2024-07-17", "2024-07-21"},
+		{"this weekend", "2024-07-05", "2024-07-07"},
+		{"next weekend", "2024-07-12", "2024-07-14"},
+		{"next week", "2024-07-08", "2024-07-15"},
+		{"the second week of July", "2024-07-08", "2024-07-15"},
+		{"the last weekend of August", "2024-08-30", "2024-09-01"},
+		{"first week of June", "2025-06-02", "2025-06-09"},
+		{"July 12-15", "2024-07-12", "2024-07-15"},
+		{"12th to 15th of July", "2024-07-12", "2024-07-15"},
+		{"between July 30 and August 2", "2024-07-30", "2024-08-02"},
+		{"July 30 - 2", "2024-07-30", "2024-08-02"},
+		{"December 30 to January 2", "2024-12-30", "2025-01-02"},
+		{"June 1 for 5 nights", "2025-06-01", "2025-06-06"},
+		{"Friday, July 12th 2024 for 2 nights", "2024-07-12", "2024-07-14"},
+		{"2024-07-12 to 2024-07-15", "2024-07-12", "2024-07-15"},
+	} {
+		t.Run(tc.expression, func(t *testing.T) {
+			stay, err := parse(tc.expression, reference)
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if start, end := stay.start.Format(time.DateOnly), stay.end.Format(time.DateOnly); start != tc.start || end != tc.end {
+				t.Errorf("parse() = %s - %s, want %s - %s (%v)", start, end, tc.start, tc.end, stay.notes)
+			}
+		})
+	}
+}
+
+func TestParseUnresolved(t *testing.T) {
+	reference := time.Date(2024, time.July, 3, 0, 0, 0, 0, time.UTC)
+
+	for _, expression := range []string{
+		"",
+		"sometime soon",
+		"July 32",
+		"February 30 for 2 nights",
+		"July 15 to July 12 2024",
+		"July 12 to 15 for 3 nights",
+		"the fifth week of February 2024",
+		"second week in",
+		"first weekend",
+		"last week of",
+	} {
+		var unresolvedErr *unresolvedError
+		if _, err := parse(expression, reference); !errors.As(err, &unresolvedErr) {
+			t.Errorf("parse(%q) error = %v, want it unresolved", expression, err)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package dates
+
+import (
+	"booking/internal/domain"
+	"context"
+)
+
+// The repositories report missing items with database.ErrNotFound.
+
+type propertiesRepository interface {
+	GetProperty(ctx context.Context, propertyId int) (*domain.Property, error)
+}
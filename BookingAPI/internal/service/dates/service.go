@@ -0,0 +1,98 @@
+// Package dates resolves the dates of a stay told in words, e.g. "next Friday
+// for three nights", into the exact dates the other operations take, as the
+// agents often get the calendar arithmetic wrong.
+package dates
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"booking/internal/database"
+	"booking/internal/domain"
+	"booking/internal/tracing"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const defaultTimeZone = "UTC"
+
+type datesService struct {
+	propertiesRepository propertiesRepository
+	now                  func() time.Time
+}
+
+func NewService(propertiesRepository propertiesRepository) *datesService {
+	return &datesService{
+		propertiesRepository: propertiesRepository,
+		now:                  time.Now,
+	}
+}
+
+// Resolve resolves the expression against the reference date or, when it is
+// nil, against today in the time zone. Without the time zone, the one of the
+// property is taken, if any is given.
+func (srv *datesService) Resolve(ctx context.Context, expression string, referenceDate *time.Time,
+	timeZone string, propertyId *int) (_ domain.DateResolution, err error) {
+
+	ctx, span := tracing.Start(ctx, "datesService.Resolve")
+	defer tracing.End(span, &err)
+
+	location, err := srv.location(ctx, timeZone, propertyId)
+	if err != nil {
+		return domain.DateResolution{}, err
+	}
+
+	var reference time.Time
+	if referenceDate != nil {
+		reference = *referenceDate
+	} else {
+		today := srv.now().In(location)
+		reference = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	stay, err := parse(expression, reference)
+	var unresolvedErr *unresolvedError
+	if errors.As(err, &unresolvedErr) {
+		return domain.DateResolution{}, domain.NewValidationError(domain.ErrUnresolvedDates,
+			domain.FieldError{Field: "expression", Message: sentence(unresolvedErr.message)})
+	} else if err != nil {
+		return domain.DateResolution{}, err
+	}
+
+	return domain.DateResolution{
+		StartDate:     openapi_types.Date{Time: stay.start},
+		EndDate:       openapi_types.Date{Time: stay.end},
+		Nights:        int(stay.end.Sub(stay.start).Hours() / 24),
+		ReferenceDate: openapi_types.Date{Time: reference},
+		TimeZone:      location.String(),
+		Explanation:   strings.Join(stay.notes, " "),
+	}, nil
+}
+
+func sentence(message string) string {
+	return strings.ToUpper(message[:1]) + message[1:] + "."
+}
+
+func (srv *datesService) location(ctx context.Context, timeZone string, propertyId *int) (*time.Location, error) {
+	if timeZone == "" && propertyId != nil {
+		property, err := srv.propertiesRepository.GetProperty(ctx, *propertyId)
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, domain.ErrPropertyNotFound
+		} else if err != nil {
+			return nil, err
+		}
+		return property.LoadLocation()
+	}
+
+	if timeZone == "" {
+		timeZone = defaultTimeZone
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, domain.NewValidationError(domain.ErrInvalidRequest,
+			domain.FieldError{Field: "timeZone", Message: "Unknown time zone, expected an IANA one, e.g. Europe/Warsaw."})
+	}
+	return location, nil
+}
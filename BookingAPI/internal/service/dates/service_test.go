@@ -0,0 +1,40 @@
+package dates
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+)
+
+func TestResolve(t *testing.T) {
+	tokyo, propertyId, unknownId := "Asia/Tokyo", 1, 2
+	srv := NewService(memory.NewPropertiesStore(domain.Property{PropertyId: 1, TimeZone: &tokyo}))
+	// late on Wednesday in UTC, already Thursday at the property in Tokyo
+	srv.now = func() time.Time { return time.Date(2024, time.July, 3, 22, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	resolution, err := srv.Resolve(ctx, "tomorrow for 2 nights", nil, "", &propertyId)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if start := resolution.StartDate.Format(time.DateOnly); start != "2024-07-05" || resolution.Nights != 2 ||
+		resolution.ReferenceDate.Format(time.DateOnly) != "2024-07-04" {
+		t.Errorf("Resolve() = %+v, want the stay from Friday 2024-07-05", resolution)
+	}
+
+	var validationErr *domain.ValidationError
+	if _, err := srv.Resolve(ctx, "today", nil, "Mars/Olympus", nil); !errors.As(err, &validationErr) ||
+		validationErr.Fields[0].Field != "timeZone" {
+		t.Errorf("Resolve() error = %v, want the time zone invalid", err)
+	}
+	if _, err := srv.Resolve(ctx, "today", nil, "", &unknownId); !errors.Is(err, domain.ErrPropertyNotFound) {
+		t.Errorf("Resolve() error = %v, want %v", err, domain.ErrPropertyNotFound)
+	}
+	if _, err := srv.Resolve(ctx, "sometime soon", nil, "", nil); !errors.Is(err, domain.ErrUnresolvedDates) {
+		t.Errorf("Resolve() error = %v, want %v", err, domain.ErrUnresolvedDates)
+	}
+}
@@ -0,0 +1,24 @@
+package drafts
+
+import (
+	"booking/internal/domain"
+	"context"
+)
+
+// The repositories report missing items with database.ErrNotFound.
+
+type draftsRepository interface {
+	PutDraft(ctx context.Context, draft domain.Draft) error
+	GetDraft(ctx context.Context, sessionId string) (*domain.Draft, error)
+	RemoveDraft(ctx context.Context, sessionId string) error
+}
+
+type propertiesRepository interface {
+	GetProperty(ctx context.Context, propertyId int) (*domain.Property, error)
+}
+
+// bookingsService books the submitted drafts, with the checks of the
+// bookings made at once.
+type bookingsService interface {
+	BookProperty(ctx context.Context, request domain.BookingRequest) (domain.BookingResponse, error)
+}
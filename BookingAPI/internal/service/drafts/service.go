@@ -0,0 +1,396 @@
+// Package drafts collects the details of a booking over the turns of an
+// agent session, telling the agent what is still to ask the customer for,
+// and books the property once they are all there.
+package drafts
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"booking/internal/database"
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"booking/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// draftTTL is how long a draft is kept after its last update.
+const draftTTL = 24 * time.Hour
+
+// updateAttempts is how many times an update is merged into the draft read
+// again when the draft has been put in the meantime.
+const updateAttempts = 3
+
+// cardDigitsShown is how many of the last digits of the card number are
+// shown in the drafts returned.
+const cardDigitsShown = 4
+
+var (
+	phoneNumber = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{5,18}[0-9]$`)
+	expiryDate  = regexp.MustCompile(`^(0[1-9]|1[0-2])/([0-9]{2})$`)
+	cvv         = regexp.MustCompile(`^[0-9]{3,4}$`)
+)
+
+type draftsService struct {
+	draftsRepository     draftsRepository
+	propertiesRepository propertiesRepository
+	bookingsService      bookingsService
+	now                  func() time.Time
+}
+
+func NewService(draftsRepository draftsRepository, propertiesRepository propertiesRepository,
+	bookingsService bookingsService) *draftsService {
+
+	return &draftsService{
+		draftsRepository:     draftsRepository,
+		propertiesRepository: propertiesRepository,
+		bookingsService:      bookingsService,
+		now:                  time.Now,
+	}
+}
+
+// Get returns the draft of the session, an empty one if there is none.
+func (srv *draftsService) Get(ctx context.Context, sessionId string) (_ domain.BookingDraft, err error) {
+	ctx, span := tracing.Start(ctx, "draftsService.Get")
+	defer tracing.End(span, &err)
+
+	draft, err := srv.getOrNew(ctx, sessionId)
+	if err != nil {
+		return domain.BookingDraft{}, err
+	}
+	return srv.check(ctx, *draft)
+}
+
+// Update sets the given fields of the draft of the session, creating it if
+// needed, and extends its expiry. The draft is put on the version it was
+// read at, so that an update made meanwhile in the same session is not
+// overwritten but read again and merged into.
+func (srv *draftsService) Update(ctx context.Context, sessionId string, fields domain.BookingDraftFields) (
+	_ domain.BookingDraft, err error) {
+
+	ctx, span := tracing.Start(ctx, "draftsService.Update")
+	defer tracing.End(span, &err)
+
+	for attempt := 1; ; attempt++ {
+		draft, err := srv.getOrNew(ctx, sessionId)
+		if err != nil {
+			return domain.BookingDraft{}, err
+		}
+		merge(&draft.Fields, fields)
+		draft.ExpiresAt = srv.now().Add(draftTTL)
+
+		err = srv.draftsRepository.PutDraft(ctx, *draft)
+		if errors.Is(err, database.ErrConditionalCheckFailed) && attempt < updateAttempts {
+			continue
+		} else if err != nil {
+			return domain.BookingDraft{}, err
+		}
+		return srv.check(ctx, *draft)
+	}
+}
+
+func (srv *draftsService) Discard(ctx context.Context, sessionId string) (err error) {
+	ctx, span := tracing.Start(ctx, "draftsService.Discard")
+	defer tracing.End(span, &err)
+
+	err = srv.draftsRepository.RemoveDraft(ctx, sessionId)
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.ErrDraftNotFound
+	}
+	return err
+}
+
+// Submit discards the draft and books the property with its details and
+// the CVV of the card, which is not kept in the draft, the draft being kept
+// if the booking fails. A draft with missing or invalid fields fails with
+// domain.ErrDraftIncomplete listing them.
+func (srv *draftsService) Submit(ctx context.Context, sessionId string, submission domain.BookingDraftSubmission) (
+	_ domain.BookingResponse, err error) {
+
+	ctx, span := tracing.Start(ctx, "draftsService.Submit")
+	defer tracing.End(span, &err)
+
+	if !cvv.MatchString(submission.Cvv) {
+		return domain.BookingResponse{}, domain.NewValidationError(domain.ErrInvalidRequest,
+			domain.FieldError{Field: "cvv", Message: "Should be 3 or 4 digits."})
+	}
+
+	draft, err := srv.draftsRepository.GetDraft(ctx, sessionId)
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.BookingResponse{}, domain.ErrDraftNotFound
+	} else if err != nil {
+		return domain.BookingResponse{}, err
+	}
+
+	checked, err := srv.check(ctx, *draft)
+	if err != nil {
+		return domain.BookingResponse{}, err
+	}
+	if !checked.Complete {
+		fields := make([]domain.FieldError, 0, len(checked.Missing)+len(checked.Invalid))
+		for _, field := range checked.Missing {
+			fields = append(fields, domain.FieldError{Field: field, Message: "Required."})
+		}
+		fields = append(fields, checked.Invalid...)
+		return domain.BookingResponse{}, domain.NewValidationError(domain.ErrDraftIncomplete, fields...)
+	}
+	span.SetAttributes(attribute.Int("property.id", *draft.Fields.PropertyId))
+
+	// the draft is removed before the booking is made, so that submitting it
+	// again, meanwhile or after, cannot book it twice, and put back if the
+	// booking fails, as a new draft, unless one has been started meanwhile
+	err = srv.draftsRepository.RemoveDraft(ctx, sessionId)
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.BookingResponse{}, domain.ErrDraftNotFound
+	} else if err != nil {
+		return domain.BookingResponse{}, err
+	}
+
+	confirmation, err := srv.bookingsService.BookProperty(ctx, bookingRequest(draft.Fields, submission.Cvv))
+	if err != nil {
+		draft.Version = 0
+		if putErr := srv.draftsRepository.PutDraft(ctx, *draft); putErr != nil {
+			logging.FromContext(ctx).Warn("unable to put back the booking draft", "error", putErr)
+		}
+		return domain.BookingResponse{}, err
+	}
+	return confirmation, nil
+}
+
+func (srv *draftsService) getOrNew(ctx context.Context, sessionId string) (*domain.Draft, error) {
+	draft, err := srv.draftsRepository.GetDraft(ctx, sessionId)
+	if errors.Is(err, database.ErrNotFound) {
+		return &domain.Draft{SessionId: sessionId, ExpiresAt: srv.now().Add(draftTTL)}, nil
+	}
+	return draft, err
+}
+
+// check lists the fields of the draft that are missing and the ones that are
+// not valid, the property being one that exists. The payment card is masked
+// in the draft returned.
+func (srv *draftsService) check(ctx context.Context, draft domain.Draft) (domain.BookingDraft, error) {
+	fields := draft.Fields
+	missing := []string{}
+	invalid := []domain.FieldError{}
+	invalidField := func(field, message string) {
+		invalid = append(invalid, domain.FieldError{Field: field, Message: message})
+	}
+
+	if fields.PropertyId == nil {
+		missing = append(missing, "propertyId")
+	} else {
+		property, err := srv.propertiesRepository.GetProperty(ctx, *fields.PropertyId)
+		if errors.Is(err, database.ErrNotFound) {
+			invalidField("propertyId", "Property not found.")
+		} else if err != nil {
+			return domain.BookingDraft{}, err
+		} else {
+			if fields.RoomTypeId != nil && !hasRoomType(*property, *fields.RoomTypeId) {
+				invalidField("roomTypeId", "Room type not found at the property.")
+			}
+			if fields.RatePlanId != nil && !hasRatePlan(*property, *fields.RatePlanId) {
+				invalidField("ratePlanId", "Rate plan not found at the property.")
+			}
+		}
+	}
+
+	if fields.CustomerName == nil || strings.TrimSpace(*fields.CustomerName) == "" {
+		missing = append(missing, "customerName")
+	}
+
+	contact := fields.ContactDetails
+	if contact == nil || (contact.Email == nil && contact.Phone == nil) {
+		missing = append(missing, "contactDetails")
+	} else {
+		if contact.Email != nil {
+			if address, err := mail.ParseAddress(*contact.Email); err != nil || address.Address != *contact.Email {
+				invalidField("contactDetails.email", "Not a valid e-mail address.")
+			}
+		}
+		if contact.Phone != nil && !phoneNumber.MatchString(*contact.Phone) {
+			invalidField("contactDetails.phone", "Not a valid phone number.")
+		}
+	}
+
+	payment := fields.PaymentInformation
+	if payment == nil || payment.CardNumber == nil {
+		missing = append(missing, "paymentInformation.cardNumber")
+	} else if !validCardNumber(*payment.CardNumber) {
+		invalidField("paymentInformation.cardNumber", "Not a valid card number.")
+	}
+	if payment == nil || payment.ExpiryDate == nil {
+		missing = append(missing, "paymentInformation.expiryDate")
+	} else {
+		if message := checkExpiryDate(*payment.ExpiryDate, srv.now()); message != "" {
+			invalidField("paymentInformation.expiryDate", message)
+		}
+	}
+
+	if fields.StartDate == nil {
+		missing = append(missing, "startDate")
+	}
+	if fields.EndDate == nil {
+		missing = append(missing, "endDate")
+	}
+	if fields.StartDate != nil && fields.EndDate != nil && !fields.EndDate.After(fields.StartDate.Time) {
+		invalidField("endDate", "End date should be after start date.")
+	}
+
+	return domain.BookingDraft{
+		SessionId: draft.SessionId,
+		Fields:    masked(fields),
+		Missing:   missing,
+		Invalid:   invalid,
+		Complete:  len(missing) == 0 && len(invalid) == 0,
+		ExpiresAt: draft.ExpiresAt,
+	}, nil
+}
+
+// merge sets the fields of the patch that are set, the ones of the nested
+// objects one by one.
+func merge(fields *domain.BookingDraftFields, patch domain.BookingDraftFields) {
+	set(&fields.PropertyId, patch.PropertyId)
+	set(&fields.CustomerName, patch.CustomerName)
+	set(&fields.StartDate, patch.StartDate)
+	set(&fields.EndDate, patch.EndDate)
+	set(&fields.RoomTypeId, patch.RoomTypeId)
+	set(&fields.RatePlanId, patch.RatePlanId)
+	set(&fields.PromoCode, patch.PromoCode)
+
+	if patch.ContactDetails != nil {
+		if fields.ContactDetails == nil {
+			fields.ContactDetails = &domain.ContactDetails{}
+		}
+		set(&fields.ContactDetails.Email, patch.ContactDetails.Email)
+		set(&fields.ContactDetails.Phone, patch.ContactDetails.Phone)
+	}
+	if patch.PaymentInformation != nil {
+		if fields.PaymentInformation == nil {
+			fields.PaymentInformation = &domain.DraftPaymentInformation{}
+		}
+		set(&fields.PaymentInformation.CardNumber, patch.PaymentInformation.CardNumber)
+		set(&fields.PaymentInformation.ExpiryDate, patch.PaymentInformation.ExpiryDate)
+	}
+	if patch.Guests != nil {
+		if fields.Guests == nil {
+			fields.Guests = &domain.Guests{}
+		}
+		set(&fields.Guests.Adults, patch.Guests.Adults)
+		set(&fields.Guests.Children, patch.Guests.Children)
+		set(&fields.Guests.Infants, patch.Guests.Infants)
+		set(&fields.Guests.Pets, patch.Guests.Pets)
+	}
+}
+
+func set[T any](field **T, value *T) {
+	if value != nil {
+		*field = value
+	}
+}
+
+// masked returns the fields with the card number masked save for its last
+// four digits and the expiry date masked, for the drafts returned.
+func masked(fields domain.BookingDraftFields) domain.BookingDraftFields {
+	payment := fields.PaymentInformation
+	if payment == nil {
+		return fields
+	}
+	var maskedPayment domain.DraftPaymentInformation
+	if payment.CardNumber != nil {
+		digits := strings.NewReplacer(" ", "", "-", "").Replace(*payment.CardNumber)
+		hidden := max(len(digits)-cardDigitsShown, 0)
+		number := strings.Repeat("*", hidden) + digits[hidden:]
+		maskedPayment.CardNumber = &number
+	}
+	if payment.ExpiryDate != nil {
+		expiryDate := "**/**"
+		maskedPayment.ExpiryDate = &expiryDate
+	}
+	fields.PaymentInformation = &maskedPayment
+	return fields
+}
+
+// bookingRequest turns a complete draft into the request it stands for.
+func bookingRequest(fields domain.BookingDraftFields, securityCode string) domain.BookingRequest {
+	return domain.BookingRequest{
+		PropertyId:     *fields.PropertyId,
+		CustomerName:   strings.TrimSpace(*fields.CustomerName),
+		ContactDetails: *fields.ContactDetails,
+		PaymentInformation: domain.PaymentInformation{
+			CardNumber: fields.PaymentInformation.CardNumber,
+			ExpiryDate: fields.PaymentInformation.ExpiryDate,
+			Cvv:        &securityCode,
+		},
+		StartDate:  *fields.StartDate,
+		EndDate:    *fields.EndDate,
+		Guests:     fields.Guests,
+		RoomTypeId: fields.RoomTypeId,
+		RatePlanId: fields.RatePlanId,
+		PromoCode:  fields.PromoCode,
+	}
+}
+
+func hasRoomType(property domain.Property, roomTypeId string) bool {
+	if property.RoomTypes == nil {
+		return false
+	}
+	return slices.ContainsFunc(*property.RoomTypes, func(roomType domain.RoomType) bool {
+		return roomType.RoomTypeId == roomTypeId
+	})
+}
+
+func hasRatePlan(property domain.Property, ratePlanId string) bool {
+	if property.RatePlans == nil {
+		return false
+	}
+	return slices.ContainsFunc(*property.RatePlans, func(plan domain.RatePlan) bool {
+		return plan.RatePlanId == ratePlanId
+	})
+}
+
+// validCardNumber checks the length and the Luhn checksum of the number,
+// spaces and dashes aside.
+func validCardNumber(number string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if len(digits) < 12 || len(digits) > 19 {
+		return false
+	}
+
+	sum := 0
+	for i := range digits {
+		digit := int(digits[len(digits)-1-i]) - '0'
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// checkExpiryDate returns what is wrong with the MM/YY expiry date, if
+// anything. A card is valid through the last day of its expiry month.
+func checkExpiryDate(value string, now time.Time) string {
+	match := expiryDate.FindStringSubmatch(value)
+	if match == nil {
+		return "Should be MM/YY."
+	}
+	month, _ := strconv.Atoi(match[1])
+	year, _ := strconv.Atoi(match[2])
+	if time.Date(2000+year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC).Before(now) {
+		return "The card has expired."
+	}
+	return ""
+}
@@ -0,0 +1,200 @@
+package drafts
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+	"booking/internal/service/bookings"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func TestSubmitDraft(t *testing.T) {
+	propertiesStore := memory.NewPropertiesStore(domain.Property{PropertyId: 1, City: "Krakow", Size: 55, Guests: 4})
+	bookingsStore := memory.NewBookingsStore()
+	srv := NewService(memory.NewDraftsStore(), propertiesStore,
+		bookings.NewService(bookingsStore, bookingsStore, bookingsStore, propertiesStore))
+	ctx := context.Background()
+
+	propertyId, name := 1, "John Doe"
+	// a month ahead, within the default stay rules
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	start := openapi_types.Date{Time: today.AddDate(0, 1, 0)}
+	draft, err := srv.Update(ctx, "session", domain.BookingDraftFields{
+		PropertyId:   &propertyId,
+		CustomerName: &name,
+		StartDate:    &start,
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want := []string{"contactDetails", "paymentInformation.cardNumber", "paymentInformation.expiryDate", "endDate"}
+	if draft.Complete || !slices.Equal(draft.Missing, want) {
+		t.Fatalf("Update() missing = %v, want %v", draft.Missing, want)
+	}
+
+	submission := domain.BookingDraftSubmission{Cvv: "123"}
+	var validationErr *domain.ValidationError
+	if _, err := srv.Submit(ctx, "session", submission); !errors.As(err, &validationErr) ||
+		!errors.Is(err, domain.ErrDraftIncomplete) || len(validationErr.Fields) != len(want) {
+		t.Fatalf("Submit() error = %v, want the missing fields", err)
+	}
+
+	email, cardNumber, expiryDate := "john.doe@example.com", "4111 1111 1111 1111", "12/99"
+	end := openapi_types.Date{Time: start.AddDate(0, 0, 2)}
+	draft, err = srv.Update(ctx, "session", domain.BookingDraftFields{
+		ContactDetails:     &domain.ContactDetails{Email: &email},
+		PaymentInformation: &domain.DraftPaymentInformation{CardNumber: &cardNumber, ExpiryDate: &expiryDate},
+		EndDate:            &end,
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !draft.Complete || *draft.Fields.CustomerName != name {
+		t.Fatalf("Update() = %+v, want it complete", draft)
+	}
+	if payment := draft.Fields.PaymentInformation; *payment.CardNumber != "************1111" ||
+		*payment.ExpiryDate != "**/**" {
+		t.Errorf("Update() paymentInformation = %s, %s, want it masked", *payment.CardNumber, *payment.ExpiryDate)
+	}
+
+	if _, err := srv.Submit(ctx, "session", domain.BookingDraftSubmission{Cvv: "12"}); !errors.As(err, &validationErr) ||
+		!errors.Is(err, domain.ErrInvalidRequest) || validationErr.Fields[0].Field != "cvv" {
+		t.Fatalf("Submit() error = %v, want the cvv invalid", err)
+	}
+	confirmation, err := srv.Submit(ctx, "session", submission)
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if confirmation.PropertyId != 1 || confirmation.TotalAmount != 110 {
+		t.Errorf("Submit() = %+v, want the booking of property 1", confirmation)
+	}
+
+	if _, err := srv.Submit(ctx, "session", submission); !errors.Is(err, domain.ErrDraftNotFound) {
+		t.Errorf("Submit() error = %v, want the draft discarded", err)
+	}
+}
+
+func TestSubmitDraftNotAvailable(t *testing.T) {
+	propertiesStore := memory.NewPropertiesStore(domain.Property{PropertyId: 1, City: "Krakow", Size: 55, Guests: 4})
+	bookingsStore := memory.NewBookingsStore()
+	bookingsService := bookings.NewService(bookingsStore, bookingsStore, bookingsStore, propertiesStore)
+	srv := NewService(memory.NewDraftsStore(), propertiesStore, bookingsService)
+	ctx := context.Background()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	propertyId, name, email := 1, "John Doe", "john.doe@example.com"
+	cardNumber, expiryDate := "4111111111111111", "12/99"
+	start := openapi_types.Date{Time: today.AddDate(0, 1, 0)}
+	end := openapi_types.Date{Time: start.AddDate(0, 0, 2)}
+	fields := domain.BookingDraftFields{
+		PropertyId:         &propertyId,
+		CustomerName:       &name,
+		ContactDetails:     &domain.ContactDetails{Email: &email},
+		PaymentInformation: &domain.DraftPaymentInformation{CardNumber: &cardNumber, ExpiryDate: &expiryDate},
+		StartDate:          &start,
+		EndDate:            &end,
+	}
+	if _, err := bookingsService.BookProperty(ctx, bookingRequest(fields, "123")); err != nil {
+		t.Fatalf("BookProperty() error = %v", err)
+	}
+	if _, err := srv.Update(ctx, "session", fields); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if _, err := srv.Submit(ctx, "session", domain.BookingDraftSubmission{Cvv: "123"}); !errors.Is(err,
+		domain.ErrPropertyNotAvailable) {
+		t.Fatalf("Submit() error = %v, want %v", err, domain.ErrPropertyNotAvailable)
+	}
+	if draft, err := srv.Get(ctx, "session"); err != nil || !draft.Complete {
+		t.Errorf("Get() = %+v, %v, want the draft kept", draft, err)
+	}
+}
+
+// racingDrafts updates the draft of the session once, as another turn of the
+// session would, between the reading and the first put of the draft.
+type racingDrafts struct {
+	draftsRepository
+	fields *domain.BookingDraftFields
+}
+
+func (store *racingDrafts) PutDraft(ctx context.Context, draft domain.Draft) error {
+	if fields := store.fields; fields != nil {
+		store.fields = nil
+		racing := domain.Draft{SessionId: draft.SessionId, Fields: *fields, ExpiresAt: draft.ExpiresAt}
+		if err := store.draftsRepository.PutDraft(ctx, racing); err != nil {
+			return err
+		}
+	}
+	return store.draftsRepository.PutDraft(ctx, draft)
+}
+
+func TestUpdateDraftConcurrently(t *testing.T) {
+	email, name := "john.doe@example.com", "John Doe"
+	store := &racingDrafts{draftsRepository: memory.NewDraftsStore(),
+		fields: &domain.BookingDraftFields{ContactDetails: &domain.ContactDetails{Email: &email}}}
+	srv := NewService(store, memory.NewPropertiesStore(), nil)
+
+	draft, err := srv.Update(context.Background(), "session", domain.BookingDraftFields{CustomerName: &name})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if fields := draft.Fields; fields.CustomerName == nil || fields.ContactDetails == nil ||
+		*fields.ContactDetails.Email != email {
+		t.Errorf("Update() = %+v, want both updates kept", fields)
+	}
+}
+
+func TestUpdateDraftExpiry(t *testing.T) {
+	srv := NewService(memory.NewDraftsStore(), memory.NewPropertiesStore(), nil)
+	now := time.Date(2024, time.July, 15, 12, 0, 0, 0, time.UTC)
+	srv.now = func() time.Time { return now }
+
+	expiryDate := "06/24"
+	draft, err := srv.Update(context.Background(), "session", domain.BookingDraftFields{
+		PaymentInformation: &domain.DraftPaymentInformation{ExpiryDate: &expiryDate},
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !draft.ExpiresAt.Equal(now.Add(draftTTL)) {
+		t.Errorf("Update() expiresAt = %v, want %v", draft.ExpiresAt, now.Add(draftTTL))
+	}
+	if len(draft.Invalid) != 1 || draft.Invalid[0].Message != "The card has expired." {
+		t.Errorf("Update() invalid = %+v, want the card expired", draft.Invalid)
+	}
+}
+
+func TestValidCardNumber(t *testing.T) {
+	for number, want := range map[string]bool{
+		"4111111111111111":    true,
+		"4111-1111-1111-1111": true,
+		"4111111111111112":    false,
+		"4111":                false,
+		"4111abcd11111111":    false,
+	} {
+		if got := validCardNumber(number); got != want {
+			t.Errorf("validCardNumber(%q) = %v, want %v", number, got, want)
+		}
+	}
+}
+
+func TestCheckExpiryDate(t *testing.T) {
+	now := time.Date(2024, time.July, 15, 0, 0, 0, 0, time.UTC)
+	for value, want := range map[string]string{
+		"07/24": "",
+		"01/30": "",
+		"06/24": "The card has expired.",
+		"13/24": "Should be MM/YY.",
+		"7/24":  "Should be MM/YY.",
+	} {
+		if got := checkExpiryDate(value, now); got != want {
+			t.Errorf("checkExpiryDate(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
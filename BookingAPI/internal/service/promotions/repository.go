@@ -0,0 +1,15 @@
+package promotions
+
+import (
+	"booking/internal/domain"
+	"context"
+)
+
+// The repositories report missing items with database.ErrNotFound and taken
+// codes with database.ErrConditionalCheckFailed.
+
+type promotionsRepository interface {
+	AddPromotion(ctx context.Context, promotion domain.Promotion) error
+	GetPromotions(ctx context.Context) ([]domain.Promotion, error)
+	RemovePromotion(ctx context.Context, code string) error
+}
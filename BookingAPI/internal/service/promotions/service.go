@@ -0,0 +1,108 @@
+// Package promotions manages the promo codes the customers redeem for a
+// discount when they book.
+package promotions
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"booking/internal/database"
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"booking/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type promotionsService struct {
+	promotionsRepository promotionsRepository
+}
+
+func NewService(promotionsRepository promotionsRepository) *promotionsService {
+	return &promotionsService{
+		promotionsRepository: promotionsRepository,
+	}
+}
+
+// Add creates the promotion with its code in upper case, as the codes are
+// matched regardless of the case. A taken code fails it with
+// domain.ErrPromotionExists.
+func (srv *promotionsService) Add(ctx context.Context, request domain.PromotionRequest) (
+	_ domain.Promotion, err error) {
+
+	code := normalize(request.Code)
+	ctx, span := tracing.Start(ctx, "promotionsService.Add", attribute.String("promotion.code", code))
+	defer tracing.End(span, &err)
+
+	if (request.DiscountPercent == nil) == (request.DiscountAmount == nil) {
+		return domain.Promotion{}, domain.NewValidationError(domain.ErrInvalidRequest, domain.FieldError{
+			Field:   "discountPercent",
+			Message: "Either the discount percent or the discount amount should be set.",
+		})
+	}
+	if request.ValidFrom != nil && request.ValidUntil != nil && !request.ValidUntil.After(*request.ValidFrom) {
+		return domain.Promotion{}, domain.NewValidationError(domain.ErrInvalidDateRange, domain.FieldError{
+			Field:   "validUntil",
+			Message: "Valid until should be after valid from.",
+		})
+	}
+
+	promotion := domain.Promotion{
+		Code:            code,
+		Description:     request.Description,
+		DiscountPercent: request.DiscountPercent,
+		DiscountAmount:  request.DiscountAmount,
+		ValidFrom:       request.ValidFrom,
+		ValidUntil:      request.ValidUntil,
+		PropertyIds:     request.PropertyIds,
+		Cities:          request.Cities,
+		MinNights:       request.MinNights,
+		MaxRedemptions:  request.MaxRedemptions,
+	}
+	err = srv.promotionsRepository.AddPromotion(ctx, promotion)
+	if errors.Is(err, database.ErrConditionalCheckFailed) {
+		return domain.Promotion{}, domain.ErrPromotionExists
+	} else if err != nil {
+		return domain.Promotion{}, err
+	}
+	logging.FromContext(ctx).Info("promotion created", "promotion", promotion)
+	return promotion, nil
+}
+
+// List returns the promotions by their code, with their redemptions so far.
+func (srv *promotionsService) List(ctx context.Context) (_ []domain.Promotion, err error) {
+	ctx, span := tracing.Start(ctx, "promotionsService.List")
+	defer tracing.End(span, &err)
+
+	promotions, err := srv.promotionsRepository.GetPromotions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if promotions == nil {
+		promotions = []domain.Promotion{}
+	}
+	return promotions, nil
+}
+
+// Remove stops the promo code from being redeemed.
+func (srv *promotionsService) Remove(ctx context.Context, code string) (err error) {
+	code = normalize(code)
+	ctx, span := tracing.Start(ctx, "promotionsService.Remove", attribute.String("promotion.code", code))
+	defer tracing.End(span, &err)
+
+	err = srv.promotionsRepository.RemovePromotion(ctx, code)
+	if errors.Is(err, database.ErrNotFound) {
+		return domain.ErrPromotionNotFound
+	} else if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("promotion removed")
+	return nil
+}
+
+// normalize returns the promo code as it is stored, matching it regardless
+// of the case and of the surrounding spaces.
+func normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,74 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+)
+
+func TestAddPromotion(t *testing.T) {
+	ctx := context.Background()
+	srv := NewService(memory.NewBookingsStore())
+	percent, amount := float32(15), float32(20)
+	from := time.Date(2024, time.November, 1, 0, 0, 0, 0, time.UTC)
+	until := from.AddDate(0, 1, 0)
+
+	promotion, err := srv.Add(ctx, domain.PromotionRequest{Code: " winter15 ", DiscountPercent: &percent,
+		ValidFrom: &from, ValidUntil: &until})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if promotion.Code != "WINTER15" || *promotion.DiscountPercent != 15 || promotion.Redemptions != 0 {
+		t.Errorf("Add() = %+v", promotion)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		request domain.PromotionRequest
+		want    error
+	}{
+		{"taken code", domain.PromotionRequest{Code: "Winter15", DiscountAmount: &amount}, domain.ErrPromotionExists},
+		{"no discount", domain.PromotionRequest{Code: "WINTER"}, domain.ErrInvalidRequest},
+		{"both discounts", domain.PromotionRequest{Code: "WINTER", DiscountPercent: &percent, DiscountAmount: &amount},
+			domain.ErrInvalidRequest},
+		{"reversed window", domain.PromotionRequest{Code: "WINTER", DiscountAmount: &amount,
+			ValidFrom: &until, ValidUntil: &from}, domain.ErrInvalidDateRange},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := srv.Add(ctx, tc.request); !errors.Is(err, tc.want) {
+				t.Errorf("Add() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if _, err := srv.Add(ctx, domain.PromotionRequest{Code: "AUTUMN", DiscountAmount: &amount}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	promotions, err := srv.List(ctx)
+	if err != nil || len(promotions) != 2 || promotions[0].Code != "AUTUMN" || promotions[1].Code != "WINTER15" {
+		t.Errorf("List() = %+v, %v, want AUTUMN and WINTER15", promotions, err)
+	}
+}
+
+func TestRemovePromotion(t *testing.T) {
+	ctx := context.Background()
+	srv := NewService(memory.NewBookingsStore())
+	amount := float32(20)
+	if _, err := srv.Add(ctx, domain.PromotionRequest{Code: "WELCOME", DiscountAmount: &amount}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := srv.Remove(ctx, "welcome"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := srv.Remove(ctx, "WELCOME"); !errors.Is(err, domain.ErrPromotionNotFound) {
+		t.Errorf("Remove() error = %v, want %v", err, domain.ErrPromotionNotFound)
+	}
+	if promotions, err := srv.List(ctx); err != nil || len(promotions) != 0 {
+		t.Errorf("List() = %+v, %v, want no promotions", promotions, err)
+	}
+}
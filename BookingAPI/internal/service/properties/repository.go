@@ -3,9 +3,18 @@ package properties
 import (
 	"booking/internal/domain"
 	"context"
+	"time"
 )
 
+// The repository reports missing items with database.ErrNotFound.
+
 type propertiesRepository interface {
 	GetProperty(ctx context.Context, id int) (*domain.Property, error)
 	Search(ctx context.Context, options domain.SearchOptions) ([]domain.Property, error)
 }
+
+// bookingsService tells whether the properties found are available for the
+// dates of the search.
+type bookingsService interface {
+	IsAvailable(ctx context.Context, property domain.Property, startDate, endDate time.Time) (bool, error)
+}
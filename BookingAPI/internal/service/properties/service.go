@@ -1,26 +1,118 @@
 package properties
 
 import (
+	"booking/internal/database"
 	"booking/internal/domain"
+	"booking/internal/logging"
+	"booking/internal/metrics"
+	"booking/internal/tracing"
 	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// unknownLocation is the location the searches finding nothing are counted in.
+const unknownLocation = "Unknown"
+
 type propertiesService struct {
 	propertiesRepository propertiesRepository
+	bookingsService      bookingsService
 }
 
-func NewService(propertyRepository propertiesRepository) *propertiesService {
-	return &propertiesService{propertyRepository}
+// NewService creates the service. The bookingsService is needed only by the
+// searches with dates.
+func NewService(propertyRepository propertiesRepository, bookingsService bookingsService) *propertiesService {
+	return &propertiesService{
+		propertiesRepository: propertyRepository,
+		bookingsService:      bookingsService,
+	}
 }
 
-func (srv *propertiesService) GetProperty(ctx context.Context, id int) (*domain.Property, error) {
+func (srv *propertiesService) GetProperty(ctx context.Context, id int) (_ *domain.Property, err error) {
+	ctx, span := tracing.Start(ctx, "propertiesService.GetProperty", attribute.Int("property.id", id))
+	defer tracing.End(span, &err)
+
 	property, err := srv.propertiesRepository.GetProperty(ctx, id)
-	if err == nil && property == nil {
+	if errors.Is(err, database.ErrNotFound) {
 		return nil, domain.ErrPropertyNotFound
 	}
 	return property, err
 }
 
-func (srv *propertiesService) Search(ctx context.Context, options domain.SearchOptions) ([]domain.Property, error) {
-	return srv.propertiesRepository.Search(ctx, options)
+func (srv *propertiesService) Search(ctx context.Context, options domain.SearchOptions) (
+	_ []domain.Property, err error) {
+
+	ctx, span := tracing.Start(ctx, "propertiesService.Search")
+	defer tracing.End(span, &err)
+
+	if options.City == nil && options.Country == nil {
+		return nil, domain.NewValidationError(domain.ErrMissingSearchLocation,
+			domain.FieldError{Field: "city", Message: "City or country is required."},
+			domain.FieldError{Field: "country", Message: "City or country is required."})
+	}
+	if err := checkDates(options); err != nil {
+		return nil, err
+	}
+
+	found, err := srv.propertiesRepository.Search(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	countSearch(ctx, options, found)
+	if options.StartDate == nil {
+		return found, nil
+	}
+
+	available := []domain.Property{}
+	for _, property := range found {
+		ok, err := srv.bookingsService.IsAvailable(ctx, property, options.StartDate.Time, options.EndDate.Time)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			available = append(available, property)
+		}
+	}
+	return available, nil
+}
+
+// countSearch counts the search in the city or the country of the properties
+// found, as they are stored, and the ones finding nothing in unknownLocation,
+// so that the values the clients send do not make up new metrics. The
+// location searched is logged instead.
+func countSearch(ctx context.Context, options domain.SearchOptions, found []domain.Property) {
+	name, searched := "City", options.City
+	if searched == nil {
+		name, searched = "Country", options.Country
+	}
+	logging.Add(ctx, "search_"+strings.ToLower(name), *searched)
+
+	location := unknownLocation
+	if len(found) > 0 {
+		location = found[0].City
+		if name == "Country" {
+			location = found[0].Country
+		}
+	}
+	metrics.Count(ctx, metrics.Searches, metrics.Dimension{Name: name, Value: location})
+}
+
+// checkDates checks that the dates of the stay are given together and in
+// order, if at all.
+func checkDates(options domain.SearchOptions) error {
+	switch {
+	case options.StartDate == nil && options.EndDate == nil:
+		return nil
+	case options.StartDate == nil:
+		return domain.NewValidationError(domain.ErrInvalidRequest,
+			domain.FieldError{Field: "startDate", Message: "Start date is required along with the end date."})
+	case options.EndDate == nil:
+		return domain.NewValidationError(domain.ErrInvalidRequest,
+			domain.FieldError{Field: "endDate", Message: "End date is required along with the start date."})
+	case !options.EndDate.After(options.StartDate.Time):
+		return domain.NewValidationError(domain.ErrInvalidDateRange,
+			domain.FieldError{Field: "endDate", Message: "End date should be after start date."})
+	}
+	return nil
 }
@@ -0,0 +1,39 @@
+package properties
+
+import (
+	"context"
+	"testing"
+
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+	"booking/internal/metrics"
+)
+
+func TestSearchCounted(t *testing.T) {
+	recorder := metrics.NewMemory()
+	metrics.SetDefault(recorder)
+	t.Cleanup(func() { metrics.SetDefault(metrics.Nop) })
+
+	srv := NewService(memory.NewPropertiesStore(
+		domain.Property{PropertyId: 1, City: "Krakow", Country: "Poland", Size: 55, Guests: 4}), nil)
+	krakow, nowhere, poland := "Krakow", "x-1234", "Poland"
+	for _, options := range []domain.SearchOptions{{City: &krakow}, {City: &nowhere}, {Country: &poland}} {
+		if _, err := srv.Search(context.Background(), options); err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		dimension metrics.Dimension
+		want      float64
+	}{
+		{metrics.Dimension{Name: "City", Value: "Krakow"}, 1},
+		{metrics.Dimension{Name: "City", Value: unknownLocation}, 1},
+		{metrics.Dimension{Name: "City", Value: nowhere}, 0},
+		{metrics.Dimension{Name: "Country", Value: "Poland"}, 1},
+	} {
+		if sum := recorder.Sum(metrics.Searches, tc.dimension); sum != tc.want {
+			t.Errorf("Sum(Searches, %+v) = %v, want %v", tc.dimension, sum, tc.want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Package tracing sets up OpenTelemetry and provides the helpers creating
+// the spans of the services. The trace IDs are generated in the X-Ray
+// format and the X-Ray trace header is propagated, so the spans join the
+// trace started for the Lambda invocation.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The exporters Setup accepts.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+const (
+	tracerName         = "booking"
+	defaultServiceName = "booking-api"
+)
+
+// Setup registers the global tracer provider exporting the spans with the
+// exporter. The OTLP exporter is configured by the standard OTEL_EXPORTER_OTLP_*
+// environment variables, it sends the spans to http://localhost:4318 by
+// default, where the collector Lambda layer listens. With ExporterNone the
+// spans are not recorded at all. The returned function flushes and stops
+// the provider.
+func Setup(ctx context.Context, exporterName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(xray.Propagator{})
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch exporterName {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("unknown traces exporter %q", exporterName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the %s exporter: %w", exporterName, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName())),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithIDGenerator(xray.NewIDGenerator()),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// serviceName names the service after the function, so every function
+// shows up on its own in the service map.
+func serviceName() string {
+	if name := os.Getenv("AWS_LAMBDA_FUNCTION_NAME"); name != "" {
+		return name
+	}
+	return defaultServiceName
+}
+
+// Flush exports the spans ended so far. A Lambda function has to call it
+// before it returns the response, as it may be frozen right after.
+func Flush(ctx context.Context) error {
+	if provider, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		return provider.ForceFlush(ctx)
+	}
+	return nil
+}
+
+// Start starts a span of an internal operation, e.g. a method of a service.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends the span, recording the error the operation returned, if any.
+// It takes a pointer to be deferred right after Start with the named
+// result of the operation:
+//
+//	ctx, span := tracing.Start(ctx, "bookingsService.Cancel")
+//	defer tracing.End(span, &err)
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// useExporter registers a tracer provider exporting the spans to memory,
+// as soon as they end, until the test is over.
+func useExporter(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+func TestSetupNone(t *testing.T) {
+	ctx := context.Background()
+	previous := otel.GetTracerProvider()
+
+	shutdown, err := Setup(ctx, ExporterNone)
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if otel.GetTracerProvider() != previous {
+		t.Errorf("Setup() registered a tracer provider, want none")
+	}
+	if err := shutdown(ctx); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+
+	_, span := Start(ctx, "service.Operation")
+	End(span, nil)
+	if span.IsRecording() || span.SpanContext().IsValid() {
+		t.Errorf("Start() span recorded, want it dropped")
+	}
+	if err := Flush(ctx); err != nil {
+		t.Errorf("Flush() error = %v", err)
+	}
+}
+
+func TestSetupUnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), "zipkin"); err == nil {
+		t.Errorf("Setup() error = nil, want the exporter rejected")
+	}
+}
+
+func TestEnd(t *testing.T) {
+	exporter := useExporter(t)
+	ctx := context.Background()
+
+	func() (err error) {
+		_, span := Start(ctx, "service.Succeed")
+		defer End(span, &err)
+		return nil
+	}()
+	func() (err error) {
+		_, span := Start(ctx, "service.Fail")
+		defer End(span, &err)
+		return errors.New("store unavailable")
+	}()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+	if spans[0].Name != "service.Succeed" || spans[0].Status.Code != codes.Unset {
+		t.Errorf("span = %s %v, want service.Succeed unset", spans[0].Name, spans[0].Status)
+	}
+	if spans[1].Name != "service.Fail" || spans[1].Status.Code != codes.Error ||
+		spans[1].Status.Description != "store unavailable" || len(spans[1].Events) != 1 {
+		t.Errorf("span = %s %v, want service.Fail with the error recorded", spans[1].Name, spans[1].Status)
+	}
+}
@@ -0,0 +1,301 @@
+package transport
+
+import (
+	"booking/configuration"
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"booking/internal/tracing"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+// AgentEvent is the event a Bedrock agent invokes the function of an action
+// group with, see
+// https://docs.aws.amazon.com/bedrock/latest/userguide/agents-lambda.html
+// The action groups defined with an OpenAPI schema set the API path and the
+// HTTP method, the ones defined with function details set the function.
+type AgentEvent struct {
+	MessageVersion          string            `json:"messageVersion"`
+	Agent                   Agent             `json:"agent"`
+	InputText               string            `json:"inputText"`
+	SessionID               string            `json:"sessionId"`
+	ActionGroup             string            `json:"actionGroup"`
+	APIPath                 string            `json:"apiPath"`
+	HTTPMethod              string            `json:"httpMethod"`
+	Function                string            `json:"function"`
+	Parameters              []AgentParameter  `json:"parameters"`
+	RequestBody             *AgentRequestBody `json:"requestBody,omitempty"`
+	SessionAttributes       map[string]string `json:"sessionAttributes"`
+	PromptSessionAttributes map[string]string `json:"promptSessionAttributes"`
+}
+
+type Agent struct {
+	Name    string `json:"name"`
+	ID      string `json:"id"`
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
+}
+
+// AgentParameter is a value the agent elicited. The value is always a
+// string, the type is the one of the schema.
+type AgentParameter struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// AgentRequestBody has the properties of the body per content type.
+type AgentRequestBody struct {
+	Content map[string]AgentContent `json:"content"`
+}
+
+type AgentContent struct {
+	Properties []AgentParameter `json:"properties"`
+}
+
+// AgentResponse is the envelope the agent expects the response in.
+type AgentResponse struct {
+	MessageVersion          string              `json:"messageVersion"`
+	Response                AgentActionResponse `json:"response"`
+	SessionAttributes       map[string]string   `json:"sessionAttributes,omitempty"`
+	PromptSessionAttributes map[string]string   `json:"promptSessionAttributes,omitempty"`
+}
+
+// AgentActionResponse is the result of either an API operation or a
+// function, depending on the event.
+type AgentActionResponse struct {
+	ActionGroup    string `json:"actionGroup"`
+	APIPath        string `json:"apiPath,omitempty"`
+	HTTPMethod     string `json:"httpMethod,omitempty"`
+	HTTPStatusCode int    `json:"httpStatusCode,omitempty"`
+	// ResponseBody has the body, as a string, per content type.
+	ResponseBody     map[string]AgentResponseBody `json:"responseBody,omitempty"`
+	Function         string                       `json:"function,omitempty"`
+	FunctionResponse *AgentFunctionResponse       `json:"functionResponse,omitempty"`
+}
+
+type AgentResponseBody struct {
+	Body string `json:"body"`
+}
+
+type AgentHandler func(ctx context.Context, event AgentEvent) (*AgentResponse, error)
+
+// StartAgent runs the Lambda function of the action group, serving the
+// operations of the server behind the agent middlewares.
+func StartAgent(config configuration.Config, ssi domain.StrictServerInterface) {
+	lambda.Start(NewAgentHandler(ssi, AgentMiddlewares(config)...))
+}
+
+// AgentMiddlewares returns the chain of the action group function. Only
+// Bedrock is allowed to invoke the function, so the requests are neither
+// authenticated nor checked against the allowed origins, but the admin
+// operations are kept from the agent.
+func AgentMiddlewares(config configuration.Config) []Middleware {
+	return []Middleware{
+		RequestID(),
+		Logger(slog.Default()),
+		Tracing(),
+		Recover(),
+		Timing(false),
+		Auth(NoAdmin()),
+		Validation(ValidationMode(config.ValidationMode)),
+	}
+}
+
+// NewAgentHandler serves the events of a Bedrock action group in-process:
+// the operation is routed on the API path and the HTTP method of the event,
+// or on the name of the function, as if the agent called the API, and the
+// response is wrapped in the envelope the agent expects. So the action group
+// may be defined with either the OpenAPI schema or the function details.
+func NewAgentHandler(ssi domain.StrictServerInterface, middlewares ...Middleware) AgentHandler {
+	handler := NewHTTPHandler(ssi, middlewares...)
+	operations := mustNewAgentOperations()
+
+	return func(ctx context.Context, event AgentEvent) (*AgentResponse, error) {
+		var httpRequest *http.Request
+		var err error
+		if event.Function != "" {
+			httpRequest, err = newAgentFunctionHTTPRequest(ctx, event, operations)
+		} else {
+			httpRequest, err = newAgentHTTPRequest(ctx, event)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		writer := newResponseWriter()
+		handler.ServeHTTP(writer, httpRequest)
+
+		if err := tracing.Flush(ctx); err != nil {
+			logging.FromContext(ctx).Warn("unable to flush the spans", "error", err)
+		}
+
+		response := AgentActionResponse{ActionGroup: event.ActionGroup}
+		if event.Function != "" {
+			response.Function = event.Function
+			response.FunctionResponse = newAgentFunctionResponse(writer)
+		} else {
+			response.APIPath = event.APIPath
+			response.HTTPMethod = event.HTTPMethod
+			response.HTTPStatusCode = writer.statusCode()
+			response.ResponseBody = map[string]AgentResponseBody{
+				ContentTypeJSON: {Body: writer.body.String()},
+			}
+		}
+		return &AgentResponse{
+			MessageVersion:          "1.0",
+			Response:                response,
+			SessionAttributes:       event.SessionAttributes,
+			PromptSessionAttributes: event.PromptSessionAttributes,
+		}, nil
+	}
+}
+
+// newAgentHTTPRequest fills the placeholders of the API path with the
+// parameters named after them, passes the other parameters in the query and
+// the properties of the request body as a JSON object.
+func newAgentHTTPRequest(ctx context.Context, event AgentEvent) (*http.Request, error) {
+	path := event.APIPath
+	query := url.Values{}
+	for _, parameter := range event.Parameters {
+		placeholder := "{" + parameter.Name + "}"
+		if strings.Contains(path, placeholder) {
+			path = strings.ReplaceAll(path, placeholder, url.PathEscape(parameter.Value))
+		} else {
+			query.Add(parameter.Name, parameter.Value)
+		}
+	}
+
+	var body []byte
+	if event.RequestBody != nil {
+		if content, ok := event.RequestBody.Content[ContentTypeJSON]; ok {
+			object := make(map[string]any, len(content.Properties))
+			for _, property := range content.Properties {
+				object[property.Name] = agentValue(property)
+			}
+			var err error
+			if body, err = json.Marshal(object); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return newAgentRequest(ctx, event, event.HTTPMethod, url.URL{Path: path, RawQuery: query.Encode()}, body)
+}
+
+// newAgentRequest creates the request of the event, in the agent session
+// and the trace of the invocation.
+func newAgentRequest(ctx context.Context, event AgentEvent, method string, target url.URL, body []byte) (
+	*http.Request, error) {
+
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, method, target.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		httpRequest.Header.Set("Content-Type", ContentTypeJSON)
+	}
+	httpRequest.Header.Set(HeaderSessionID, event.SessionID)
+	if lambdaContext, ok := lambdacontext.FromContext(ctx); ok {
+		httpRequest.Header.Set(HeaderRequestID, lambdaContext.AwsRequestID)
+	}
+	// set by the Lambda runtime, its segment is the parent of the spans
+	if traceID, ok := ctx.Value("x-amzn-trace-id").(string); ok && traceID != "" {
+		httpRequest.Header.Set(HeaderTraceID, traceID)
+	}
+	return httpRequest, nil
+}
+
+// agentValue converts the value of the property to its type in the schema.
+// Objects are sent either as JSON or, by some models, as XML elements, e.g.
+// <email>john@example.com</email>. Values that do not match their type are
+// passed as strings, for the validation to report them.
+func agentValue(property AgentParameter) any {
+	switch property.Type {
+	case "integer":
+		if value, err := strconv.ParseInt(property.Value, 10, 64); err == nil {
+			return value
+		}
+	case "number":
+		if value, err := strconv.ParseFloat(property.Value, 64); err == nil {
+			return value
+		}
+	case "boolean":
+		if value, err := strconv.ParseBool(property.Value); err == nil {
+			return value
+		}
+	case "object", "array":
+		var value any
+		if err := json.Unmarshal([]byte(property.Value), &value); err == nil {
+			return value
+		}
+		if object, err := parseXMLElements(property.Value); err == nil {
+			return object
+		}
+	}
+	return property.Value
+}
+
+var errNoElements = errors.New("no XML elements")
+
+// parseXMLElements turns a sequence of XML elements into an object with a
+// field per element, holding either the text or the nested elements.
+func parseXMLElements(value string) (map[string]any, error) {
+	decoder := xml.NewDecoder(strings.NewReader("<root>" + value + "</root>"))
+	// the root element
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	object, _, err := decodeXMLElement(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if len(object) == 0 {
+		return nil, errNoElements
+	}
+	return object, nil
+}
+
+// decodeXMLElement reads the content of an element up to its end, returning
+// the nested elements and the text.
+func decodeXMLElement(decoder *xml.Decoder) (map[string]any, string, error) {
+	object := map[string]any{}
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, "", err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			nested, nestedText, err := decodeXMLElement(decoder)
+			if err != nil {
+				return nil, "", err
+			}
+			if len(nested) > 0 {
+				object[token.Name.Local] = nested
+			} else {
+				object[token.Name.Local] = strings.TrimSpace(nestedText)
+			}
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			return object, text.String(), nil
+		}
+	}
+}
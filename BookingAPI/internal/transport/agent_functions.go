@@ -0,0 +1,297 @@
+package transport
+
+import (
+	"booking/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// AgentFunction is the definition of an operation as a function of an
+// action group, see
+// https://docs.aws.amazon.com/bedrock/latest/userguide/agents-action-function.html
+type AgentFunction struct {
+	Name        string                            `json:"name"`
+	Description string                            `json:"description,omitempty"`
+	Parameters  map[string]AgentFunctionParameter `json:"parameters,omitempty"`
+}
+
+// AgentFunctionParameter describes a parameter of a function. The functions
+// take only scalar and array parameters, so the fields of nested objects of
+// the request bodies are flattened, e.g. contactDetails_email.
+type AgentFunctionParameter struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required"`
+}
+
+// AgentFunctionResponse is the envelope of the result of a function.
+type AgentFunctionResponse struct {
+	// ResponseState is REPROMPT when the request was invalid, for the
+	// model to fix the parameters, and empty otherwise.
+	ResponseState string `json:"responseState,omitempty"`
+	// ResponseBody has the body per content type, always TEXT.
+	ResponseBody map[string]AgentResponseBody `json:"responseBody"`
+}
+
+const agentFunctionBodyType = "TEXT"
+
+// agentFieldSeparator joins the names of the fields of nested objects.
+const agentFieldSeparator = "_"
+
+// AdminTag tags the operations of the administrators, which the agent is
+// not given.
+const AdminTag = "admin"
+
+// agentOperation is what a function call is routed to.
+type agentOperation struct {
+	method     string
+	path       string
+	parameters map[string]agentOperationParameter
+}
+
+type agentOperationParameter struct {
+	// in is path, query or body
+	in string
+	// field is the path to the field of the body
+	field []string
+	// schemaType converts the value of the parameter
+	schemaType string
+}
+
+// AgentFunctions derives the functions from the operations of the API
+// specification, one per operation named after its capitalized ID, the
+// admin ones aside.
+func AgentFunctions(spec *openapi3.T) []AgentFunction {
+	functions, _ := agentOperations(spec)
+	return functions
+}
+
+func agentOperations(spec *openapi3.T) ([]AgentFunction, map[string]agentOperation) {
+	var functions []AgentFunction
+	operations := map[string]agentOperation{}
+
+	paths := spec.Paths.Map()
+	for _, path := range sortedKeys(paths) {
+		pathItem := paths[path]
+		methods := pathItem.Operations()
+		for _, method := range sortedKeys(methods) {
+			operation := methods[method]
+			if operation.OperationID == "" || slices.Contains(operation.Tags, AdminTag) {
+				continue
+			}
+			function := AgentFunction{
+				Name:        agentFunctionName(operation.OperationID),
+				Description: agentDescription(operation.Summary, operation.Description),
+				Parameters:  map[string]AgentFunctionParameter{},
+			}
+			target := agentOperation{
+				method:     method,
+				path:       path,
+				parameters: map[string]agentOperationParameter{},
+			}
+
+			for _, parameterRef := range append(pathItem.Parameters, operation.Parameters...) {
+				parameter := parameterRef.Value
+				if parameter.In != openapi3.ParameterInPath && parameter.In != openapi3.ParameterInQuery {
+					continue
+				}
+				schema := parameter.Schema.Value
+				function.Parameters[parameter.Name] = AgentFunctionParameter{
+					Type:        agentType(schema),
+					Description: agentParameterDescription(parameter.Description, schema),
+					Required:    parameter.Required,
+				}
+				target.parameters[parameter.Name] = agentOperationParameter{
+					in:         parameter.In,
+					schemaType: agentType(schema),
+				}
+			}
+
+			if body := operation.RequestBody; body != nil && body.Value != nil {
+				if content := body.Value.Content.Get(ContentTypeJSON); content != nil && content.Schema != nil {
+					addAgentBodyParameters(&function, &target, content.Schema.Value, nil, body.Value.Required)
+				}
+			}
+
+			functions = append(functions, function)
+			operations[function.Name] = target
+		}
+	}
+	return functions, operations
+}
+
+// addAgentBodyParameters adds a parameter per field of the body, flattening
+// the nested objects. A field is required when all its parents are.
+func addAgentBodyParameters(function *AgentFunction, target *agentOperation, schema *openapi3.Schema,
+	parents []string, required bool) {
+
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name].Value
+		field := append(slices.Clone(parents), name)
+		fieldRequired := required && slices.Contains(schema.Required, name)
+
+		if property.Type == openapi3.TypeObject && len(property.Properties) > 0 {
+			addAgentBodyParameters(function, target, property, field, fieldRequired)
+			continue
+		}
+		parameterName := strings.Join(field, agentFieldSeparator)
+		function.Parameters[parameterName] = AgentFunctionParameter{
+			Type:        agentType(property),
+			Description: agentParameterDescription("", property),
+			Required:    fieldRequired,
+		}
+		target.parameters[parameterName] = agentOperationParameter{
+			in:         "body",
+			field:      field,
+			schemaType: agentType(property),
+		}
+	}
+}
+
+// agentFunctionName is the operation ID as oapi-codegen capitalizes it in
+// the embedded specification, so that the functions derived from api.yaml
+// and from the embedded one have the same names.
+func agentFunctionName(operationID string) string {
+	return strings.ToUpper(operationID[:1]) + operationID[1:]
+}
+
+// agentType maps the type of the schema to one of the types the functions
+// accept: string, number, integer, boolean or array.
+func agentType(schema *openapi3.Schema) string {
+	switch schema.Type {
+	case openapi3.TypeInteger, openapi3.TypeNumber, openapi3.TypeBoolean, openapi3.TypeArray:
+		return schema.Type
+	}
+	return openapi3.TypeString
+}
+
+func agentDescription(summary, description string) string {
+	if description != "" {
+		return description
+	}
+	return summary
+}
+
+// agentParameterDescription tells the model the format or the values the
+// value is expected in and an example, when the schema has them.
+func agentParameterDescription(description string, schema *openapi3.Schema) string {
+	description = agentDescription(description, schema.Description)
+	switch schema.Format {
+	case "date":
+		description += " Date in the YYYY-MM-DD format."
+	case "uuid":
+		description += " UUID."
+	}
+	if len(schema.Enum) > 0 {
+		values := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			values[i] = fmt.Sprint(value)
+		}
+		description += " One of " + strings.Join(values, ", ") + "."
+	}
+	if schema.Example != nil {
+		description += fmt.Sprintf(" Example: %v.", schema.Example)
+	}
+	return strings.TrimSpace(description)
+}
+
+func mustNewAgentOperations() map[string]agentOperation {
+	spec, err := domain.GetSwagger()
+	if err != nil {
+		panic(fmt.Errorf("unable to load embedded API specification: %w", err))
+	}
+	_, operations := agentOperations(spec)
+	return operations
+}
+
+// newAgentFunctionHTTPRequest routes the call of the function to its
+// operation. The calls to unknown functions are routed nowhere, to be
+// answered with the not found problem.
+func newAgentFunctionHTTPRequest(ctx context.Context, event AgentEvent, operations map[string]agentOperation) (
+	*http.Request, error) {
+
+	operation, ok := operations[event.Function]
+	if !ok {
+		return newAgentRequest(ctx, event, http.MethodPost, url.URL{Path: "/" + url.PathEscape(event.Function)}, nil)
+	}
+
+	path := operation.path
+	query := url.Values{}
+	var body map[string]any
+	for _, parameter := range event.Parameters {
+		target, ok := operation.parameters[parameter.Name]
+		if !ok {
+			// left for the validation to report
+			query.Add(parameter.Name, parameter.Value)
+			continue
+		}
+		switch target.in {
+		case openapi3.ParameterInPath:
+			path = strings.ReplaceAll(path, "{"+parameter.Name+"}", url.PathEscape(parameter.Value))
+		case openapi3.ParameterInQuery:
+			query.Add(parameter.Name, parameter.Value)
+		default:
+			if body == nil {
+				body = map[string]any{}
+			}
+			setField(body, target.field, agentValue(AgentParameter{Type: target.schemaType, Value: parameter.Value}))
+		}
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	return newAgentRequest(ctx, event, operation.method, url.URL{Path: path, RawQuery: query.Encode()}, data)
+}
+
+// setField sets the field of the object, creating the nested objects.
+func setField(object map[string]any, field []string, value any) {
+	for _, name := range field[:len(field)-1] {
+		nested, ok := object[name].(map[string]any)
+		if !ok {
+			nested = map[string]any{}
+			object[name] = nested
+		}
+		object = nested
+	}
+	object[field[len(field)-1]] = value
+}
+
+// newAgentFunctionResponse wraps the response of the operation. The body is
+// passed as is, the JSON being as readable to the model as any text, and
+// the empty ones are replaced with a sentence.
+func newAgentFunctionResponse(writer *responseWriter) *AgentFunctionResponse {
+	body := writer.body.String()
+	if body == "" {
+		body = "The operation succeeded."
+	}
+	response := &AgentFunctionResponse{
+		ResponseBody: map[string]AgentResponseBody{
+			agentFunctionBodyType: {Body: body},
+		},
+	}
+	if status := writer.statusCode(); status >= 400 && status < 500 {
+		response.ResponseState = "REPROMPT"
+	}
+	return response
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,219 @@
+package transport
+
+import (
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+	"booking/internal/service/bookings"
+	"booking/internal/service/dates"
+	"booking/internal/service/drafts"
+	"booking/internal/service/properties"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// stayStart and stayEnd are the dates of a stay a month ahead, within the
+// default stay rules of the properties.
+var (
+	stayStart = time.Now().UTC().AddDate(0, 1, 0).Format(time.DateOnly)
+	stayEnd   = time.Now().UTC().AddDate(0, 1, 2).Format(time.DateOnly)
+)
+
+func newAgentTestHandler() AgentHandler {
+	propertiesStore := memory.NewPropertiesStore(domain.Property{PropertyId: 1, City: "Krakow", Size: 55, Guests: 4})
+	bookingsStore := memory.NewBookingsStore()
+	bookingsService := bookings.NewService(bookingsStore, bookingsStore, bookingsStore, propertiesStore)
+	server := NewServer(Services{
+		Properties: properties.NewService(propertiesStore, bookingsService),
+		Bookings:   bookingsService,
+		Drafts:     drafts.NewService(memory.NewDraftsStore(), propertiesStore, bookingsService),
+		Dates:      dates.NewService(propertiesStore),
+	})
+	return NewAgentHandler(server, RequestID(), Recover())
+}
+
+func TestAgentHandlerParameters(t *testing.T) {
+	handler := newAgentTestHandler()
+
+	response, err := handler(context.Background(), AgentEvent{
+		ActionGroup: "Booking",
+		APIPath:     "/properties/{propertyId}/availability",
+		HTTPMethod:  http.MethodGet,
+		Parameters: []AgentParameter{
+			{Name: "propertyId", Type: "integer", Value: "1"},
+			{Name: "startDate", Type: "string", Value: stayStart},
+			{Name: "endDate", Type: "string", Value: stayEnd},
+		},
+	})
+	if err != nil {
+		t.Fatalf("handler() error = %v", err)
+	}
+	if response.Response.HTTPStatusCode != http.StatusOK || response.Response.APIPath != "/properties/{propertyId}/availability" {
+		t.Fatalf("handler() = %+v, want 200 for the API path of the event", response.Response)
+	}
+
+	var availability domain.Availability
+	if err := json.Unmarshal([]byte(response.Response.ResponseBody[ContentTypeJSON].Body), &availability); err != nil {
+		t.Fatal(err)
+	}
+	if !availability.Available || availability.Price != 110 {
+		t.Errorf("handler() body = %+v, want available for 110", availability)
+	}
+}
+
+func TestAgentHandlerRequestBody(t *testing.T) {
+	handler := newAgentTestHandler()
+
+	event := AgentEvent{
+		APIPath:    "/bookings",
+		HTTPMethod: http.MethodPost,
+		RequestBody: &AgentRequestBody{Content: map[string]AgentContent{ContentTypeJSON: {Properties: []AgentParameter{
+			{Name: "propertyId", Type: "integer", Value: "1"},
+			{Name: "customerName", Type: "string", Value: "John Doe"},
+			{Name: "contactDetails", Type: "object", Value: "<email>john.doe@example.com</email>"},
+			{Name: "paymentInformation", Type: "object", Value: `{"cardNumber": "4111111111111111"}`},
+			{Name: "startDate", Type: "string", Value: stayStart},
+			{Name: "endDate", Type: "string", Value: stayEnd},
+		}}}},
+	}
+	response, err := handler(context.Background(), event)
+	if err != nil {
+		t.Fatalf("handler() error = %v", err)
+	}
+	if response.Response.HTTPStatusCode != http.StatusCreated {
+		t.Errorf("handler() = %+v, want 201", response.Response)
+	}
+}
+
+func TestParseXMLElements(t *testing.T) {
+	object, err := parseXMLElements("<email>john.doe@example.com</email>\n<address><city>Krakow</city></address>")
+	if err != nil {
+		t.Fatalf("parseXMLElements() error = %v", err)
+	}
+	address, _ := object["address"].(map[string]any)
+	if object["email"] != "john.doe@example.com" || address["city"] != "Krakow" {
+		t.Errorf("parseXMLElements() = %v, want the text of the elements", object)
+	}
+
+	if _, err := parseXMLElements("john.doe@example.com"); err == nil {
+		t.Error("parseXMLElements() error = nil, want an error without elements")
+	}
+}
+
+func TestAgentHandlerFunction(t *testing.T) {
+	handler := newAgentTestHandler()
+
+	response, err := handler(context.Background(), AgentEvent{
+		ActionGroup: "Booking",
+		Function:    "BookProperty",
+		Parameters: []AgentParameter{
+			{Name: "propertyId", Type: "integer", Value: "1"},
+			{Name: "customerName", Type: "string", Value: "John Doe"},
+			{Name: "contactDetails_email", Type: "string", Value: "john.doe@example.com"},
+			{Name: "paymentInformation_cardNumber", Type: "string", Value: "4111111111111111"},
+			{Name: "startDate", Type: "string", Value: stayStart},
+			{Name: "endDate", Type: "string", Value: stayEnd},
+		},
+	})
+	if err != nil {
+		t.Fatalf("handler() error = %v", err)
+	}
+	if response.Response.Function != "BookProperty" || response.Response.FunctionResponse.ResponseState != "" {
+		t.Fatalf("handler() = %+v, want the booking made", response.Response)
+	}
+
+	var booking domain.BookingResponse
+	body := response.Response.FunctionResponse.ResponseBody[agentFunctionBodyType].Body
+	if err := json.Unmarshal([]byte(body), &booking); err != nil {
+		t.Fatal(err)
+	}
+	if booking.TotalAmount != 110 {
+		t.Errorf("handler() body = %s, want the booking", body)
+	}
+}
+
+func TestAgentHandlerFunctionReprompts(t *testing.T) {
+	handler := newAgentTestHandler()
+
+	for _, event := range []AgentEvent{
+		{Function: "GetProperty", Parameters: []AgentParameter{{Name: "propertyId", Type: "integer", Value: "one"}}},
+		{Function: "BookRoom"},
+	} {
+		response, err := handler(context.Background(), event)
+		if err != nil {
+			t.Fatalf("handler(%s) error = %v", event.Function, err)
+		}
+		if response.Response.FunctionResponse.ResponseState != "REPROMPT" {
+			t.Errorf("handler(%s) = %+v, want REPROMPT", event.Function, response.Response.FunctionResponse)
+		}
+	}
+}
+
+func TestAgentFunctions(t *testing.T) {
+	spec, err := domain.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, function := range AgentFunctions(spec) {
+		if function.Name != "BookProperty" {
+			continue
+		}
+		if parameter := function.Parameters["contactDetails_email"]; parameter.Type != "string" || parameter.Required {
+			t.Errorf("contactDetails_email = %+v, want an optional string", parameter)
+		}
+		if parameter := function.Parameters["propertyId"]; parameter.Type != "integer" || !parameter.Required {
+			t.Errorf("propertyId = %+v, want a required integer", parameter)
+		}
+		return
+	}
+	t.Error("AgentFunctions() has no BookProperty function")
+}
+
+func TestAgentHandlerDraft(t *testing.T) {
+	handler := newAgentTestHandler()
+
+	call := func(function string, parameters ...AgentParameter) string {
+		t.Helper()
+		response, err := handler(context.Background(), AgentEvent{
+			SessionID:  "session",
+			Function:   function,
+			Parameters: parameters,
+		})
+		if err != nil {
+			t.Fatalf("handler(%s) error = %v", function, err)
+		}
+		if state := response.Response.FunctionResponse.ResponseState; state != "" {
+			t.Fatalf("handler(%s) = %+v, want it done", function, response.Response.FunctionResponse)
+		}
+		return response.Response.FunctionResponse.ResponseBody[agentFunctionBodyType].Body
+	}
+
+	var draft domain.BookingDraft
+	body := call("UpdateBookingDraft",
+		AgentParameter{Name: "propertyId", Type: "integer", Value: "1"},
+		AgentParameter{Name: "startDate", Type: "string", Value: stayStart},
+		AgentParameter{Name: "endDate", Type: "string", Value: stayEnd})
+	if err := json.Unmarshal([]byte(body), &draft); err != nil {
+		t.Fatal(err)
+	}
+	if draft.SessionId != "session" || len(draft.Missing) != 4 {
+		t.Fatalf("handler() body = %s, want the draft of the session with 4 fields missing", body)
+	}
+
+	call("UpdateBookingDraft",
+		AgentParameter{Name: "customerName", Type: "string", Value: "John Doe"},
+		AgentParameter{Name: "contactDetails_phone", Type: "string", Value: "+48 123 456 789"},
+		AgentParameter{Name: "paymentInformation_cardNumber", Type: "string", Value: "4111111111111111"},
+		AgentParameter{Name: "paymentInformation_expiryDate", Type: "string", Value: "12/99"})
+	var booking domain.BookingResponse
+	body = call("SubmitBookingDraft", AgentParameter{Name: "cvv", Type: "string", Value: "123"})
+	if err := json.Unmarshal([]byte(body), &booking); err != nil {
+		t.Fatal(err)
+	}
+	if booking.CustomerName != "John Doe" || booking.TotalAmount != 110 {
+		t.Errorf("handler() body = %s, want the booking", body)
+	}
+}
@@ -0,0 +1,83 @@
+package transport
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const HeaderAPIKey = "X-Api-Key"
+
+// adminPathPrefix starts the paths of the admin operations.
+const adminPathPrefix = "/admin/"
+
+var (
+	errInvalidAPIKey = errors.New("missing or invalid API key")
+	errAdminDisabled = errors.New("admin operations are disabled")
+)
+
+// Authenticator verifies the credentials of a request.
+type Authenticator func(r *http.Request) error
+
+// Auth rejects the requests the authenticator does not accept with 401.
+// A nil authenticator lets every request through.
+func Auth(authenticate Authenticator) Middleware {
+	return func(next http.Handler) http.Handler {
+		if authenticate == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := authenticate(r); err != nil {
+				writeProblem(w, r, problemUnauthorized.problem(sentence(err.Error()), nil))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// APIKey accepts the requests carrying the key in the X-Api-Key header.
+// It returns nil, disabling the authentication, when the key is empty.
+func APIKey(key string) Authenticator {
+	if key == "" {
+		return nil
+	}
+	return func(r *http.Request) error {
+		provided := r.Header.Get(HeaderAPIKey)
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(key)) != 1 {
+			return errInvalidAPIKey
+		}
+		return nil
+	}
+}
+
+// AdminAPIKey accepts the requests of the admin operations carrying the admin
+// key in the X-Api-Key header and leaves the other requests to authenticate.
+// Without the admin key the admin operations are disabled, whether or not
+// the other requests are authenticated.
+func AdminAPIKey(adminKey string, authenticate Authenticator) Authenticator {
+	admin := APIKey(adminKey)
+	if admin == nil {
+		admin = func(r *http.Request) error { return errAdminDisabled }
+	}
+	return func(r *http.Request) error {
+		if strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+			return admin(r)
+		}
+		if authenticate == nil {
+			return nil
+		}
+		return authenticate(r)
+	}
+}
+
+// NoAdmin rejects the requests of the admin operations.
+func NoAdmin() Authenticator {
+	return func(r *http.Request) error {
+		if strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+			return errAdminDisabled
+		}
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminAPIKey(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		adminKey, apiKey string
+		path, key        string
+		accepted         bool
+	}{
+		{"admin key", "admin", "api", "/admin/blocks/1", "admin", true},
+		{"api key on admin path", "admin", "api", "/admin/blocks/1", "api", false},
+		{"admin key on other path", "admin", "api", "/properties/1", "admin", false},
+		{"api key on other path", "admin", "api", "/properties/1", "api", true},
+		{"admin disabled", "", "api", "/admin/blocks/1", "api", false},
+		{"other path without admin key", "", "api", "/properties/1", "api", true},
+		{"admin disabled without auth", "", "", "/admin/blocks/1", "", false},
+		{"other path open without keys", "", "", "/properties/1", "", true},
+		{"other path open with admin key", "admin", "", "/properties/1", "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", tc.path, nil)
+			request.Header.Set(HeaderAPIKey, tc.key)
+
+			authenticate := AdminAPIKey(tc.adminKey, APIKey(tc.apiKey))
+			accepted := authenticate == nil || authenticate(request) == nil
+			if accepted != tc.accepted {
+				t.Errorf("accepted = %v, want %v", accepted, tc.accepted)
+			}
+		})
+	}
+}
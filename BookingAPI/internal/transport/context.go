@@ -0,0 +1,31 @@
+package transport
+
+import "context"
+
+type contextKey int
+
+const (
+	eventContextKey contextKey = iota
+	requestIDContextKey
+)
+
+func withEvent(ctx context.Context, event Request) context.Context {
+	return context.WithValue(ctx, eventContextKey, event)
+}
+
+// EventFromContext returns the API Gateway event the request originates from.
+func EventFromContext(ctx context.Context) (Request, bool) {
+	event, ok := ctx.Value(eventContextKey).(Request)
+	return event, ok
+}
+
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// RequestIDFromContext returns the ID assigned to the request by the
+// RequestID middleware, or an empty string outside of a request.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey).(string)
+	return requestID
+}
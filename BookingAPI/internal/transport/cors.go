@@ -0,0 +1,39 @@
+package transport
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var corsAllowedHeaders = []string{"Content-Type", HeaderAPIKey, HeaderRequestID, HeaderSessionID}
+var corsAllowedMethods = []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodOptions}
+
+// CORS sets the cross-origin headers for the allowed origins, "*" allowing
+// any of them, and answers the preflight requests. The credentials are not
+// allowed: the API key is sent in a header, not a cookie, and browsers
+// refuse them along with any origin anyway.
+func CORS(allowedOrigins []string) Middleware {
+	allowAny := slices.Contains(allowedOrigins, "*")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			switch {
+			case allowAny:
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			case origin != "" && slices.Contains(allowedOrigins, origin):
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Add("Vary", "Origin")
+			}
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(corsAllowedHeaders, ", "))
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(corsAllowedMethods, ", "))
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
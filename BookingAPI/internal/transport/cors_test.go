@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORS(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		preflight      bool
+		allowOrigin    string
+		vary           string
+		status         int
+	}{
+		{"any origin", []string{"*"}, "https://example.com", false, "*", "", http.StatusOK},
+		{"allowed origin", []string{"https://example.com"}, "https://example.com", false, "https://example.com",
+			"Origin", http.StatusOK},
+		{"other origin", []string{"https://example.com"}, "https://example.org", false, "", "", http.StatusOK},
+		{"preflight", []string{"https://example.com"}, "https://example.com", true, "https://example.com",
+			"Origin", http.StatusNoContent},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := CORS(tc.allowedOrigins)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			request := httptest.NewRequest(http.MethodGet, "/properties/1", nil)
+			request.Header.Set("Origin", tc.origin)
+			if tc.preflight {
+				request.Method = http.MethodOptions
+				request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tc.status {
+				t.Errorf("status = %d, want %d", recorder.Code, tc.status)
+			}
+			if allowOrigin := recorder.Header().Get("Access-Control-Allow-Origin"); allowOrigin != tc.allowOrigin {
+				t.Errorf("header Access-Control-Allow-Origin = %q, want %q", allowOrigin, tc.allowOrigin)
+			}
+			if vary := recorder.Header().Get("Vary"); vary != tc.vary {
+				t.Errorf("header Vary = %q, want %q", vary, tc.vary)
+			}
+			if credentials := recorder.Header().Get("Access-Control-Allow-Credentials"); credentials != "" {
+				t.Errorf("header Access-Control-Allow-Credentials = %q, want none", credentials)
+			}
+		})
+	}
+}
@@ -1,9 +1,50 @@
 package transport
 
-// create a error message variable
-const ErrorMethodNotAllowed = "method not allowed"
+import (
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"errors"
+	"net/http"
+)
 
-// Create a struct to hold aws error as string
-type ErrorBody struct {
-	ErrorMsg string `json:"error,omitempty"`
+// requestErrorHandler reports parameters and bodies that could not be bound
+// to the operation's request object.
+func requestErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	field := ""
+	var invalidFormatErr *domain.InvalidParamFormatError
+	var requiredErr *domain.RequiredParamError
+	var tooManyValuesErr *domain.TooManyValuesForParamError
+	var unmarshalingErr *domain.UnmarshalingParamError
+	switch {
+	case errors.As(err, &invalidFormatErr):
+		field = invalidFormatErr.ParamName
+	case errors.As(err, &requiredErr):
+		field = requiredErr.ParamName
+	case errors.As(err, &tooManyValuesErr):
+		field = tooManyValuesErr.ParamName
+	case errors.As(err, &unmarshalingErr):
+		field = unmarshalingErr.ParamName
+	}
+
+	writeProblem(w, r, problemInvalidRequest.problem("The request could not be read.",
+		[]domain.FieldError{{Field: field, Message: err.Error()}}))
+}
+
+// responseErrorHandler maps the errors returned by the operations to problem
+// details through the registry. Unexpected errors are logged and hidden
+// behind a generic problem, so that internals are not leaked to the caller.
+func responseErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	problem, ok := Errors.Problem(err)
+	if !ok {
+		logging.FromContext(r.Context()).Error("unexpected error", "error", err)
+	}
+	writeProblem(w, r, problem)
+}
+
+func routeNotFound(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, problemRouteNotFound.problem("", nil))
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, problemMethodNotAllowed.problem("", nil))
 }
@@ -0,0 +1,174 @@
+package transport
+
+import (
+	"booking/configuration"
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"booking/internal/tracing"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/go-chi/chi/v5"
+)
+
+// aliasing the types to keep lines short
+type Request = events.APIGatewayProxyRequest
+type Response = events.APIGatewayProxyResponse
+
+type LambdaHandler func(ctx context.Context, request Request) (*Response, error)
+
+// Start runs the Lambda function serving the operations of the server behind
+// the middlewares shared by all the functions.
+func Start(config configuration.Config, ssi domain.StrictServerInterface) {
+	lambda.Start(NewHandler(ssi, Middlewares(config)...))
+}
+
+// NewHTTPHandler routes HTTP requests to the operations of the given strict
+// server. Parameters and bodies are bound by the generated code, so the
+// server only has to call the services. The middlewares wrap the routing,
+// the first one being the outermost.
+func NewHTTPHandler(ssi domain.StrictServerInterface, middlewares ...Middleware) http.Handler {
+	router := chi.NewRouter()
+	router.NotFound(routeNotFound)
+	router.MethodNotAllowed(methodNotAllowed)
+
+	strictMiddlewares := []domain.StrictMiddlewareFunc{nameSpan}
+	strictHandler := domain.NewStrictHandlerWithOptions(ssi, strictMiddlewares, domain.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc:  requestErrorHandler,
+		ResponseErrorHandlerFunc: responseErrorHandler,
+	})
+	return chain(domain.HandlerWithOptions(strictHandler, domain.ChiServerOptions{
+		BaseRouter:       router,
+		ErrorHandlerFunc: requestErrorHandler,
+	}), middlewares...)
+}
+
+// NewHandler serves API Gateway proxy events with NewHTTPHandler. The
+// request continues the X-Ray trace of the invocation and the spans are
+// flushed before the response is returned.
+func NewHandler(ssi domain.StrictServerInterface, middlewares ...Middleware) LambdaHandler {
+	handler := NewHTTPHandler(ssi, middlewares...)
+
+	return func(ctx context.Context, request Request) (*Response, error) {
+		httpRequest, err := newHTTPRequest(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		// set by the Lambda runtime, its segment is the parent of the spans
+		if traceID, ok := ctx.Value("x-amzn-trace-id").(string); ok && traceID != "" {
+			httpRequest.Header.Set(HeaderTraceID, traceID)
+		}
+
+		writer := newResponseWriter()
+		handler.ServeHTTP(writer, httpRequest)
+
+		if err := tracing.Flush(ctx); err != nil {
+			logging.FromContext(ctx).Warn("unable to flush the spans", "error", err)
+		}
+		return writer.response(), nil
+	}
+}
+
+func newHTTPRequest(ctx context.Context, request Request) (*http.Request, error) {
+	body := []byte(request.Body)
+	if request.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = decoded
+	}
+
+	query := url.Values{}
+	for name, values := range request.MultiValueQueryStringParameters {
+		query[name] = values
+	}
+	for name, value := range request.QueryStringParameters {
+		if _, ok := query[name]; !ok {
+			query.Set(name, value)
+		}
+	}
+
+	target := url.URL{Path: request.Path, RawQuery: query.Encode()}
+	httpRequest, err := http.NewRequestWithContext(withEvent(ctx, request), request.HTTPMethod,
+		target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range request.MultiValueHeaders {
+		for _, value := range values {
+			httpRequest.Header.Add(name, value)
+		}
+	}
+	for name, value := range request.Headers {
+		if httpRequest.Header.Get(name) == "" {
+			httpRequest.Header.Set(name, value)
+		}
+	}
+	return httpRequest, nil
+}
+
+// responseWriter collects what the router writes, so that it can be returned
+// to API Gateway as a proxy response.
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{header: http.Header{}}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(data)
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *responseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// writeTo replays the collected response on another writer.
+func (w *responseWriter) writeTo(target http.ResponseWriter) {
+	for name, values := range w.header {
+		target.Header()[name] = values
+	}
+	target.WriteHeader(w.statusCode())
+	target.Write(w.body.Bytes())
+}
+
+func (w *responseWriter) response() *Response {
+	headers := map[string]string{}
+	for name, values := range w.header {
+		headers[name] = strings.Join(values, ",")
+	}
+
+	return &Response{
+		StatusCode: w.statusCode(),
+		Headers:    headers,
+		Body:       w.body.String(),
+	}
+}
@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+	"booking/internal/service/properties"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestNewHTTPRequest(t *testing.T) {
+	event := events.APIGatewayProxyRequest{
+		HTTPMethod:            http.MethodPost,
+		Path:                  "/properties/search",
+		QueryStringParameters: map[string]string{"format": "agent", "page": "2"},
+		MultiValueQueryStringParameters: map[string][]string{
+			"page": {"1", "2"},
+		},
+		Headers: map[string]string{"Content-Type": ContentTypeJSON, "X-Api-Key": "single"},
+		MultiValueHeaders: map[string][]string{
+			"X-Api-Key": {"first", "second"},
+		},
+		Body:            base64.StdEncoding.EncodeToString([]byte(`{"city": "Krakow"}`)),
+		IsBase64Encoded: true,
+	}
+
+	request, err := newHTTPRequest(context.Background(), event)
+	if err != nil {
+		t.Fatalf("newHTTPRequest() error = %v", err)
+	}
+	if request.Method != http.MethodPost || request.URL.Path != "/properties/search" {
+		t.Errorf("request = %s %s, want POST /properties/search", request.Method, request.URL.Path)
+	}
+	query := request.URL.Query()
+	if query.Get("format") != "agent" || !slices.Equal(query["page"], []string{"1", "2"}) {
+		t.Errorf("query = %v, want format agent and the pages of the multi-value parameters", query)
+	}
+	if keys := request.Header.Values(HeaderAPIKey); !slices.Equal(keys, []string{"first", "second"}) {
+		t.Errorf("header %s = %v, want the multi-value header", HeaderAPIKey, keys)
+	}
+	if contentType := request.Header.Get("Content-Type"); contentType != ContentTypeJSON {
+		t.Errorf("header Content-Type = %q, want %q", contentType, ContentTypeJSON)
+	}
+	if body, _ := io.ReadAll(request.Body); string(body) != `{"city": "Krakow"}` {
+		t.Errorf("body = %q, want it decoded", body)
+	}
+	if got, ok := EventFromContext(request.Context()); !ok || got.Path != event.Path {
+		t.Errorf("EventFromContext() = %v, want the event", ok)
+	}
+
+	event.Body = "not base64"
+	if _, err := newHTTPRequest(context.Background(), event); err == nil {
+		t.Errorf("newHTTPRequest() error = nil, want the body rejected")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	store := memory.NewPropertiesStore(domain.Property{PropertyId: 1, City: "Krakow", Country: "Poland", Size: 55,
+		Bedrooms: 2, Guests: 4})
+	server := NewServer(Services{Properties: properties.NewService(store, nil)})
+	vary := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Origin")
+			w.Header().Add("Vary", "Accept")
+			next.ServeHTTP(w, r)
+		})
+	}
+	handler := NewHandler(server, vary)
+
+	for _, tc := range []struct {
+		name        string
+		event       events.APIGatewayProxyRequest
+		status      int
+		contentType string
+	}{
+		{"path parameter", events.APIGatewayProxyRequest{
+			HTTPMethod:     http.MethodGet,
+			Path:           "/properties/1",
+			PathParameters: map[string]string{"propertyId": "1"},
+		}, http.StatusOK, ContentTypeJSON},
+		{"query string", events.APIGatewayProxyRequest{
+			HTTPMethod:            http.MethodGet,
+			Path:                  "/properties/1",
+			QueryStringParameters: map[string]string{"format": "agent"},
+		}, http.StatusOK, ContentTypeAgent},
+		{"base64 body", events.APIGatewayProxyRequest{
+			HTTPMethod:      http.MethodPost,
+			Path:            "/properties/search",
+			Headers:         map[string]string{"Content-Type": ContentTypeJSON},
+			Body:            base64.StdEncoding.EncodeToString([]byte(`{"city": "Krakow"}`)),
+			IsBase64Encoded: true,
+		}, http.StatusOK, ContentTypeJSON},
+		{"error", events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       "/properties/2",
+		}, http.StatusNotFound, ContentTypeProblem},
+		{"unknown route", events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       "/unknown",
+		}, http.StatusNotFound, ContentTypeProblem},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := handler(context.Background(), tc.event)
+			if err != nil {
+				t.Fatalf("handler() error = %v", err)
+			}
+			if response.StatusCode != tc.status {
+				t.Errorf("status = %d, want %d: %s", response.StatusCode, tc.status, response.Body)
+			}
+			if contentType := response.Headers["Content-Type"]; contentType != tc.contentType {
+				t.Errorf("header Content-Type = %q, want %q", contentType, tc.contentType)
+			}
+			if vary := response.Headers["Vary"]; vary != "Origin,Accept" {
+				t.Errorf("header Vary = %q, want the values joined", vary)
+			}
+			if !json.Valid([]byte(response.Body)) {
+				t.Errorf("body = %q, want JSON", response.Body)
+			}
+		})
+	}
+}
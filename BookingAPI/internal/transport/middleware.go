@@ -0,0 +1,143 @@
+package transport
+
+import (
+	"booking/configuration"
+	"booking/internal/logging"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HeaderRequestID = "X-Request-Id"
+	// HeaderSessionID carries the ID of the agent session the request is
+	// made in, set by the Bedrock action group.
+	HeaderSessionID = "X-Agent-Session-Id"
+)
+
+// Middleware decorates the HTTP handler that serves the API operations.
+type Middleware func(next http.Handler) http.Handler
+
+// chain applies the middlewares so that the first one is the outermost.
+func chain(handler http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// Middlewares returns the chain shared by all the functions. The requests
+// are logged with the default logger.
+func Middlewares(config configuration.Config) []Middleware {
+	return []Middleware{
+		RequestID(),
+		Logger(slog.Default()),
+		Tracing(),
+		Recover(),
+		Timing(config.Enabled(configuration.FeatureServerTiming)),
+		CORS(config.AllowedOrigins),
+		Auth(AdminAPIKey(config.AdminAPIKey, APIKey(config.APIKey))),
+		Validation(ValidationMode(config.ValidationMode)),
+	}
+}
+
+// Recover turns a panic in the handler into a 500 response instead of
+// crashing the function.
+func Recover() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					logging.FromContext(r.Context()).Error("panic serving the request",
+						"panic", fmt.Sprint(recovered), "stack", string(debug.Stack()))
+					writeProblem(w, r, problemInternal.problem("", nil))
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequestID propagates the ID of the request from the X-Request-Id header or
+// from the API Gateway request context, generating one if neither is set.
+// The ID is stored in the request context and echoed in the response.
+func RequestID() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(HeaderRequestID)
+			if requestID == "" {
+				if event, ok := EventFromContext(r.Context()); ok {
+					requestID = event.RequestContext.RequestID
+				}
+			}
+			if requestID == "" {
+				requestID = uuid.NewString()
+			}
+
+			w.Header().Set(HeaderRequestID, requestID)
+			next.ServeHTTP(w, r.WithContext(withRequestID(r.Context(), requestID)))
+		})
+	}
+}
+
+// Logger seeds the logger of the request, carried in its context, with the
+// request ID and the ID of the agent session, if any, so that all the lines
+// logged while serving the request can be correlated.
+func Logger(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestLogger := logger.With("request_id", RequestIDFromContext(r.Context()))
+			if sessionID := r.Header.Get(HeaderSessionID); sessionID != "" {
+				requestLogger = requestLogger.With("session_id", sessionID)
+			}
+			next.ServeHTTP(w, r.WithContext(logging.NewContext(r.Context(), requestLogger)))
+		})
+	}
+}
+
+// Timing logs the status and the duration of every request and, when
+// serverTiming is set, reports the latter in the Server-Timing header.
+func Timing(serverTiming bool) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			writer := &timingWriter{ResponseWriter: w, start: start, serverTiming: serverTiming}
+			next.ServeHTTP(writer, r)
+
+			logging.FromContext(r.Context()).Info("request served",
+				"method", r.Method, "path", r.URL.Path, "status", writer.status,
+				"duration_ms", float64(time.Since(start).Microseconds())/1000)
+		})
+	}
+}
+
+// timingWriter captures the status and sets the timing header right before
+// the response headers are sent.
+type timingWriter struct {
+	http.ResponseWriter
+	start        time.Time
+	serverTiming bool
+	status       int
+}
+
+func (w *timingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+		if w.serverTiming {
+			duration := float64(time.Since(w.start).Microseconds()) / 1000
+			w.Header().Set("Server-Timing", fmt.Sprintf("app;dur=%.3f", duration))
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timingWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
@@ -0,0 +1,142 @@
+package transport
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	ContentTypeJSON    = "application/json"
+	ContentTypeProblem = "application/problem+json"
+
+	problemTypePrefix = "urn:booking:problem:"
+)
+
+// problemType describes a class of problems: the status of the response,
+// the stable code clients can rely on and a short human readable title.
+type problemType struct {
+	status int
+	code   string
+	title  string
+}
+
+func (pt problemType) problem(detail string, fields []domain.FieldError) domain.Problem {
+	problem := domain.Problem{
+		Type:   problemTypePrefix + strings.ReplaceAll(pt.code, "_", "-"),
+		Title:  pt.title,
+		Status: pt.status,
+		Code:   pt.code,
+	}
+	if detail != "" {
+		problem.Detail = &detail
+	}
+	if len(fields) > 0 {
+		problem.Errors = &fields
+	}
+	return problem
+}
+
+var (
+	problemInvalidRequest   = problemType{http.StatusBadRequest, "invalid_request", "Invalid request"}
+	problemUnauthorized     = problemType{http.StatusUnauthorized, "unauthorized", "Unauthorized"}
+	problemRouteNotFound    = problemType{http.StatusNotFound, "route_not_found", "Route not found"}
+	problemMethodNotAllowed = problemType{http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed"}
+	problemInternal         = problemType{http.StatusInternalServerError, "internal_error", "Internal server error"}
+)
+
+// ErrorRegistry maps errors to the problem details they are reported with.
+type ErrorRegistry struct {
+	entries []registryEntry
+}
+
+type registryEntry struct {
+	target error
+	problemType
+}
+
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{}
+}
+
+// Register reports the errors matching target, as tested by errors.Is, with
+// the given status, code and title. Earlier registrations take precedence.
+func (registry *ErrorRegistry) Register(target error, status int, code, title string) *ErrorRegistry {
+	registry.entries = append(registry.entries, registryEntry{
+		target:      target,
+		problemType: problemType{status, code, title},
+	})
+	return registry
+}
+
+// Problem returns the problem details for the error. The field errors of
+// a domain.ValidationError and the violations of a domain.StayRulesError in
+// the chain are included. Errors that are not registered are reported as
+// internal errors, without any detail.
+func (registry *ErrorRegistry) Problem(err error) (domain.Problem, bool) {
+	var fields []domain.FieldError
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		fields = validationErr.Fields
+	}
+
+	for _, entry := range registry.entries {
+		if errors.Is(err, entry.target) {
+			problem := entry.problem(sentence(entry.target.Error()), fields)
+			var rulesErr *domain.StayRulesError
+			if errors.As(err, &rulesErr) {
+				problem.Violations = &rulesErr.Violations
+			}
+			return problem, true
+		}
+	}
+	return problemInternal.problem("", nil), false
+}
+
+// Errors is the registry of the errors returned by the services.
+var Errors = NewErrorRegistry().
+	Register(domain.ErrPropertyNotFound, http.StatusNotFound, "property_not_found", "Property not found").
+	Register(domain.ErrBookingNotFound, http.StatusNotFound, "booking_not_found", "Booking not found").
+	Register(domain.ErrRoomTypeNotFound, http.StatusNotFound, "room_type_not_found", "Room type not found").
+	Register(domain.ErrRatePlanNotFound, http.StatusNotFound, "rate_plan_not_found", "Rate plan not found").
+	Register(domain.ErrBlockNotFound, http.StatusNotFound, "block_not_found", "Block not found").
+	Register(domain.ErrPromotionNotFound, http.StatusNotFound, "promotion_not_found", "Promotion not found").
+	Register(domain.ErrPromotionExists, http.StatusConflict, "promotion_exists", "Promotion already exists").
+	Register(domain.ErrPropertyNotAvailable, http.StatusConflict, "property_not_available", "Property not available").
+	Register(domain.ErrNotCancellable, http.StatusConflict, "booking_not_cancellable", "Booking not cancellable").
+	Register(domain.ErrDraftNotFound, http.StatusNotFound, "booking_draft_not_found", "Booking draft not found").
+	Register(domain.ErrDraftIncomplete, http.StatusBadRequest, "booking_draft_incomplete", "Booking draft incomplete").
+	Register(domain.ErrUnresolvedDates, http.StatusBadRequest, "unresolved_dates", "Unresolved dates").
+	Register(domain.ErrInvalidDateRange, http.StatusBadRequest, "invalid_date_range", "Invalid date range").
+	Register(domain.ErrStayRules, http.StatusBadRequest, "stay_rules_violated", "Stay rules violated").
+	Register(domain.ErrCapacityExceeded, http.StatusBadRequest, "capacity_exceeded", "Capacity exceeded").
+	Register(domain.ErrPetsNotAllowed, http.StatusBadRequest, "pets_not_allowed", "Pets not allowed").
+	Register(domain.ErrInvalidPromoCode, http.StatusBadRequest, "invalid_promo_code", "Invalid promo code").
+	Register(domain.ErrMissingSearchLocation, http.StatusBadRequest, "missing_search_location", "Missing search location").
+	Register(domain.ErrInvalidRequest, http.StatusBadRequest, "invalid_request", "Invalid request").
+	Register(database.ErrThrottled, http.StatusServiceUnavailable, "throttled", "Service temporarily unavailable")
+
+func writeProblem(w http.ResponseWriter, r *http.Request, problem domain.Problem) {
+	if problem.Instance == nil {
+		instance := r.URL.Path
+		problem.Instance = &instance
+	}
+
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		logging.FromContext(r.Context()).Error("unable to write the problem", "error", err)
+	}
+}
+
+// sentence turns an error message into a sentence the agent can read back.
+func sentence(message string) string {
+	if message == "" {
+		return ""
+	}
+	return strings.ToUpper(message[:1]) + message[1:] + "."
+}
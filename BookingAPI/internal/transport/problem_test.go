@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"booking/internal/database"
+	"booking/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseErrorProblem(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		err         error
+		status      int
+		problemType string
+		title       string
+		detail      string
+		fields      int
+		violations  int
+	}{
+		{"registered", domain.ErrPropertyNotFound, http.StatusNotFound, "urn:booking:problem:property-not-found",
+			"Property not found", "Property not found.", 0, 0},
+		{"wrapped", fmt.Errorf("get property 1: %w", domain.ErrBookingNotFound), http.StatusNotFound,
+			"urn:booking:problem:booking-not-found", "Booking not found", "Booking not found.", 0, 0},
+		{"validation", domain.NewValidationError(domain.ErrInvalidRequest,
+			domain.FieldError{Field: "cvv", Message: "Should be 3 or 4 digits."}), http.StatusBadRequest,
+			"urn:booking:problem:invalid-request", "Invalid request", "Invalid request.", 1, 0},
+		{"stay rules", fmt.Errorf("book: %w", &domain.StayRulesError{Violations: []domain.RuleViolation{
+			{Field: "endDate", Message: "Stay at least 2 nights.", Rule: "minNights"}}}), http.StatusBadRequest,
+			"urn:booking:problem:stay-rules-violated", "Stay rules violated", "Stay breaks the rules of the property.",
+			0, 1},
+		{"store", fmt.Errorf("put booking: %w", database.ErrThrottled), http.StatusServiceUnavailable,
+			"urn:booking:problem:throttled", "Service temporarily unavailable", "", 0, 0},
+		{"store validation", fmt.Errorf("put booking: %w", database.ErrValidation), http.StatusInternalServerError,
+			"urn:booking:problem:internal-error", "Internal server error", "", 0, 0},
+		{"unregistered", errors.New("connection refused by 10.0.0.1"), http.StatusInternalServerError,
+			"urn:booking:problem:internal-error", "Internal server error", "", 0, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			responseErrorHandler(recorder, httptest.NewRequest(http.MethodGet, "/properties/1", nil), tc.err)
+
+			if recorder.Code != tc.status {
+				t.Errorf("status = %d, want %d", recorder.Code, tc.status)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != ContentTypeProblem {
+				t.Errorf("content type = %q, want %q", contentType, ContentTypeProblem)
+			}
+			body := recorder.Body.String()
+			var problem domain.Problem
+			if err := json.Unmarshal([]byte(body), &problem); err != nil {
+				t.Fatalf("unable to decode the problem: %v", err)
+			}
+
+			if problem.Status != tc.status || problem.Type != tc.problemType || problem.Title != tc.title {
+				t.Errorf("problem = %d %q %q, want %d %q %q", problem.Status, problem.Type, problem.Title,
+					tc.status, tc.problemType, tc.title)
+			}
+			if detail := problem.Detail; tc.detail != "" && (detail == nil || *detail != tc.detail) {
+				t.Errorf("problem detail = %v, want %q", detail, tc.detail)
+			}
+			if problem.Instance == nil || *problem.Instance != "/properties/1" {
+				t.Errorf("problem instance = %v, want the path", problem.Instance)
+			}
+			if fields := problem.Errors; tc.fields > 0 && (fields == nil || len(*fields) != tc.fields) ||
+				tc.fields == 0 && fields != nil {
+				t.Errorf("problem errors = %v, want %d", fields, tc.fields)
+			}
+			if violations := problem.Violations; tc.violations > 0 &&
+				(violations == nil || len(*violations) != tc.violations) || tc.violations == 0 && violations != nil {
+				t.Errorf("problem violations = %v, want %d", violations, tc.violations)
+			}
+			if strings.Contains(body, "10.0.0.1") {
+				t.Errorf("problem = %s, want the message of the error hidden", body)
+			}
+		})
+	}
+}
+
+func TestRegistryPrecedence(t *testing.T) {
+	specific := fmt.Errorf("specific: %w", domain.ErrInvalidRequest)
+	registry := NewErrorRegistry().
+		Register(specific, http.StatusConflict, "specific", "Specific").
+		Register(domain.ErrInvalidRequest, http.StatusBadRequest, "invalid_request", "Invalid request")
+
+	if problem, ok := registry.Problem(fmt.Errorf("wrapped: %w", specific)); !ok || problem.Code != "specific" {
+		t.Errorf("Problem() = %+v, %v, want the earlier registration", problem, ok)
+	}
+	if problem, ok := registry.Problem(domain.ErrInvalidRequest); !ok || problem.Code != "invalid_request" {
+		t.Errorf("Problem() = %+v, %v, want invalid_request", problem, ok)
+	}
+	if problem, ok := registry.Problem(domain.ErrPropertyNotFound); ok || problem.Status != http.StatusInternalServerError ||
+		problem.Detail != nil {
+		t.Errorf("Problem() = %+v, %v, want an internal error without detail", problem, ok)
+	}
+}
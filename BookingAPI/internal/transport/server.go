@@ -0,0 +1,265 @@
+package transport
+
+import (
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"context"
+	"time"
+)
+
+// server implements the operations of the generated strict server interface
+// by delegating them to the business services. Errors are returned as they
+// are and mapped to responses in one place, see responseErrorHandler. The
+// IDs of the property, the booking and the block, and the promo code, are
+// added to the request logger. The results are summarized for the requests
+// made with format=agent.
+type server struct {
+	propertiesService propertiesService
+	bookingsService   bookingsService
+	draftsService     draftsService
+	datesService      datesService
+	blocksService     blocksService
+	promotionsService promotionsService
+}
+
+// Services are the business services a server delegates to. A function
+// that serves only a subset of the operations may leave the unused services
+// nil.
+type Services struct {
+	Properties propertiesService
+	Bookings   bookingsService
+	Drafts     draftsService
+	Dates      datesService
+	Blocks     blocksService
+	Promotions promotionsService
+}
+
+// NewServer creates a server backed by the given services.
+func NewServer(services Services) *server {
+	return &server{
+		propertiesService: services.Properties,
+		bookingsService:   services.Bookings,
+		draftsService:     services.Drafts,
+		datesService:      services.Dates,
+		blocksService:     services.Blocks,
+		promotionsService: services.Promotions,
+	}
+}
+
+func (srv *server) SearchProperties(ctx context.Context, request domain.SearchPropertiesRequestObject) (
+	domain.SearchPropertiesResponseObject, error) {
+
+	properties, err := srv.propertiesService.Search(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if agentFormat(request.Params.Format) {
+		return domain.SearchProperties200ApplicationVndBookingAgentPlusJSONResponse(
+			summarizeSearch(*request.Body, properties)), nil
+	}
+	if properties == nil {
+		properties = []domain.Property{}
+	}
+	return domain.SearchProperties200JSONResponse(properties), nil
+}
+
+func (srv *server) GetProperty(ctx context.Context, request domain.GetPropertyRequestObject) (
+	domain.GetPropertyResponseObject, error) {
+
+	logging.Add(ctx, "property_id", request.PropertyId)
+	property, err := srv.propertiesService.GetProperty(ctx, request.PropertyId)
+	if err != nil {
+		return nil, err
+	}
+	if agentFormat(request.Params.Format) {
+		return domain.GetProperty200ApplicationVndBookingAgentPlusJSONResponse(summarizeProperty(*property)), nil
+	}
+	return domain.GetProperty200JSONResponse(*property), nil
+}
+
+func (srv *server) GetAvailability(ctx context.Context, request domain.GetAvailabilityRequestObject) (
+	domain.GetAvailabilityResponseObject, error) {
+
+	logging.Add(ctx, "property_id", request.PropertyId)
+	guests := &domain.Guests{
+		Adults:   request.Params.Adults,
+		Children: request.Params.Children,
+		Pets:     request.Params.Pets,
+	}
+	availability, err := srv.bookingsService.GetAvailability(ctx, request.PropertyId,
+		request.Params.StartDate.Time, request.Params.EndDate.Time, guests, request.Params.RoomTypeId,
+		request.Params.RatePlanId, request.Params.PromoCode)
+	if err != nil {
+		return nil, err
+	}
+	if agentFormat(request.Params.Format) {
+		return domain.GetAvailability200ApplicationVndBookingAgentPlusJSONResponse(summarizeAvailability(
+			request.PropertyId, request.Params.StartDate.Time, request.Params.EndDate.Time, availability)), nil
+	}
+	return domain.GetAvailability200JSONResponse(availability), nil
+}
+
+func (srv *server) BookProperty(ctx context.Context, request domain.BookPropertyRequestObject) (
+	domain.BookPropertyResponseObject, error) {
+
+	logging.Add(ctx, "property_id", request.Body.PropertyId)
+	confirmation, err := srv.bookingsService.BookProperty(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	logging.Add(ctx, "booking_id", confirmation.BookingId)
+	if agentFormat(request.Params.Format) {
+		return domain.BookProperty201ApplicationVndBookingAgentPlusJSONResponse(summarizeBooking(confirmation)), nil
+	}
+	return domain.BookProperty201JSONResponse(confirmation), nil
+}
+
+func (srv *server) CancelBooking(ctx context.Context, request domain.CancelBookingRequestObject) (
+	domain.CancelBookingResponseObject, error) {
+
+	logging.Add(ctx, "booking_id", request.BookingId)
+	err := srv.bookingsService.Cancel(ctx, request.BookingId)
+	if err != nil {
+		return nil, err
+	}
+	return domain.CancelBooking204Response{}, nil
+}
+
+func (srv *server) GetBookingDraft(ctx context.Context, request domain.GetBookingDraftRequestObject) (
+	domain.GetBookingDraftResponseObject, error) {
+
+	draft, err := srv.draftsService.Get(ctx, request.Params.XAgentSessionId)
+	if err != nil {
+		return nil, err
+	}
+	return domain.GetBookingDraft200JSONResponse(draft), nil
+}
+
+func (srv *server) UpdateBookingDraft(ctx context.Context, request domain.UpdateBookingDraftRequestObject) (
+	domain.UpdateBookingDraftResponseObject, error) {
+
+	draft, err := srv.draftsService.Update(ctx, request.Params.XAgentSessionId, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Fields.PropertyId != nil {
+		logging.Add(ctx, "property_id", *draft.Fields.PropertyId)
+	}
+	return domain.UpdateBookingDraft200JSONResponse(draft), nil
+}
+
+func (srv *server) DiscardBookingDraft(ctx context.Context, request domain.DiscardBookingDraftRequestObject) (
+	domain.DiscardBookingDraftResponseObject, error) {
+
+	err := srv.draftsService.Discard(ctx, request.Params.XAgentSessionId)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DiscardBookingDraft204Response{}, nil
+}
+
+func (srv *server) SubmitBookingDraft(ctx context.Context, request domain.SubmitBookingDraftRequestObject) (
+	domain.SubmitBookingDraftResponseObject, error) {
+
+	confirmation, err := srv.draftsService.Submit(ctx, request.Params.XAgentSessionId, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	logging.Add(ctx, "property_id", confirmation.PropertyId)
+	logging.Add(ctx, "booking_id", confirmation.BookingId)
+	if agentFormat(request.Params.Format) {
+		return domain.SubmitBookingDraft201ApplicationVndBookingAgentPlusJSONResponse(summarizeBooking(confirmation)), nil
+	}
+	return domain.SubmitBookingDraft201JSONResponse(confirmation), nil
+}
+
+func (srv *server) ResolveDates(ctx context.Context, request domain.ResolveDatesRequestObject) (
+	domain.ResolveDatesResponseObject, error) {
+
+	var referenceDate *time.Time
+	if request.Params.ReferenceDate != nil {
+		referenceDate = &request.Params.ReferenceDate.Time
+	}
+	var timeZone string
+	if request.Params.TimeZone != nil {
+		timeZone = *request.Params.TimeZone
+	}
+
+	if request.Params.PropertyId != nil {
+		logging.Add(ctx, "property_id", *request.Params.PropertyId)
+	}
+
+	resolution, err := srv.datesService.Resolve(ctx, request.Params.Expression, referenceDate, timeZone,
+		request.Params.PropertyId)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ResolveDates200JSONResponse(resolution), nil
+}
+
+func (srv *server) ListBlocks(ctx context.Context, request domain.ListBlocksRequestObject) (
+	domain.ListBlocksResponseObject, error) {
+
+	logging.Add(ctx, "property_id", request.PropertyId)
+	blocks, err := srv.blocksService.List(ctx, request.PropertyId)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ListBlocks200JSONResponse(blocks), nil
+}
+
+func (srv *server) CreateBlock(ctx context.Context, request domain.CreateBlockRequestObject) (
+	domain.CreateBlockResponseObject, error) {
+
+	logging.Add(ctx, "property_id", request.PropertyId)
+	block, err := srv.blocksService.Add(ctx, request.PropertyId, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	logging.Add(ctx, "block_id", block.BlockId)
+	return domain.CreateBlock201JSONResponse(block), nil
+}
+
+func (srv *server) RemoveBlock(ctx context.Context, request domain.RemoveBlockRequestObject) (
+	domain.RemoveBlockResponseObject, error) {
+
+	logging.Add(ctx, "block_id", request.BlockId)
+	err := srv.blocksService.Remove(ctx, request.BlockId)
+	if err != nil {
+		return nil, err
+	}
+	return domain.RemoveBlock204Response{}, nil
+}
+
+func (srv *server) ListPromotions(ctx context.Context, request domain.ListPromotionsRequestObject) (
+	domain.ListPromotionsResponseObject, error) {
+
+	promotions, err := srv.promotionsService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ListPromotions200JSONResponse(promotions), nil
+}
+
+func (srv *server) CreatePromotion(ctx context.Context, request domain.CreatePromotionRequestObject) (
+	domain.CreatePromotionResponseObject, error) {
+
+	logging.Add(ctx, "promo_code", request.Body.Code)
+	promotion, err := srv.promotionsService.Add(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return domain.CreatePromotion201JSONResponse(promotion), nil
+}
+
+func (srv *server) RemovePromotion(ctx context.Context, request domain.RemovePromotionRequestObject) (
+	domain.RemovePromotionResponseObject, error) {
+
+	logging.Add(ctx, "promo_code", request.Code)
+	err := srv.promotionsService.Remove(ctx, request.Code)
+	if err != nil {
+		return nil, err
+	}
+	return domain.RemovePromotion204Response{}, nil
+}
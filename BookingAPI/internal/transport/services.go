@@ -0,0 +1,46 @@
+package transport
+
+import (
+	"booking/internal/domain"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type propertiesService interface {
+	GetProperty(ctx context.Context, id int) (*domain.Property, error)
+	Search(ctx context.Context, options domain.SearchOptions) ([]domain.Property, error)
+}
+
+type bookingsService interface {
+	BookProperty(ctx context.Context, request domain.BookingRequest) (domain.BookingResponse, error)
+	GetAvailability(ctx context.Context, propertyId int, startDate, endDate time.Time, guests *domain.Guests,
+		roomTypeId, ratePlanId, promoCode *string) (domain.Availability, error)
+	Cancel(ctx context.Context, bookingId uuid.UUID) error
+}
+
+type draftsService interface {
+	Get(ctx context.Context, sessionId string) (domain.BookingDraft, error)
+	Update(ctx context.Context, sessionId string, fields domain.BookingDraftFields) (domain.BookingDraft, error)
+	Discard(ctx context.Context, sessionId string) error
+	Submit(ctx context.Context, sessionId string, submission domain.BookingDraftSubmission) (
+		domain.BookingResponse, error)
+}
+
+type datesService interface {
+	Resolve(ctx context.Context, expression string, referenceDate *time.Time, timeZone string,
+		propertyId *int) (domain.DateResolution, error)
+}
+
+type blocksService interface {
+	Add(ctx context.Context, propertyId int, request domain.BlockRequest) (domain.Block, error)
+	List(ctx context.Context, propertyId int) ([]domain.Block, error)
+	Remove(ctx context.Context, blockId uuid.UUID) error
+}
+
+type promotionsService interface {
+	Add(ctx context.Context, request domain.PromotionRequest) (domain.Promotion, error)
+	List(ctx context.Context) ([]domain.Promotion, error)
+	Remove(ctx context.Context, code string) error
+}
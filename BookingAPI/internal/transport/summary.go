@@ -0,0 +1,188 @@
+package transport
+
+import (
+	"booking/internal/domain"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentTypeAgent is the media type of the summaries returned to the
+// requests made with format=agent.
+const ContentTypeAgent = "application/vnd.booking.agent+json"
+
+// agentFormat tells whether the summary was requested. The generated code has
+// a type of the format parameter per operation.
+func agentFormat[Format ~string](format *Format) bool {
+	return format != nil && string(*format) == string(domain.FormatAgent)
+}
+
+// The summaries keep only the details that matter to pick a property and to
+// book it, in sentences the agent can read back as they are.
+
+func summarizeSearch(options domain.SearchOptions, properties []domain.Property) domain.AgentSummary {
+	items := make([]domain.AgentSummaryItem, 0, len(properties))
+	for _, property := range properties {
+		items = append(items, domain.AgentSummaryItem{
+			Id:   strconv.Itoa(property.PropertyId),
+			Text: describeProperty(property),
+		})
+	}
+
+	var where string
+	switch {
+	case options.City != nil:
+		where = " in " + *options.City
+	case options.Country != nil:
+		where = " in " + *options.Country
+	}
+	summary := fmt.Sprintf("Found %s%s.", plural(len(properties), "property", "properties"), where)
+	if len(properties) == 0 {
+		summary = fmt.Sprintf("No property matches the search%s.", where)
+	}
+	return domain.AgentSummary{Summary: summary, Items: &items}
+}
+
+func summarizeProperty(property domain.Property) domain.AgentSummary {
+	sentences := []string{fmt.Sprintf("Property %d: %s", property.PropertyId, describeProperty(property))}
+	for _, detail := range []*string{property.Layout, property.ArchitecturalStyle,
+		property.FeatureDescription, property.RuleDescription} {
+
+		if detail != nil && *detail != "" {
+			sentences = append(sentences, fullStop(*detail))
+		}
+	}
+	if property.RoomTypes != nil && len(*property.RoomTypes) > 0 {
+		types := make([]string, len(*property.RoomTypes))
+		for i, roomType := range *property.RoomTypes {
+			types[i] = fmt.Sprintf("%s (%s) for up to %s, %d m²", roomType.Name, roomType.RoomTypeId,
+				plural(roomType.Guests, "guest", "guests"), roomType.Size)
+		}
+		sentences = append(sentences, "Room types: "+strings.Join(types, "; ")+".")
+	}
+	if property.RatePlans != nil {
+		for _, plan := range *property.RatePlans {
+			sentence := fmt.Sprintf("Rate plan %s (%s)", plan.Name, plan.RatePlanId)
+			if plan.Description != nil && *plan.Description != "" {
+				sentence += ": " + *plan.Description
+			}
+			sentences = append(sentences, fullStop(sentence))
+		}
+	}
+	return domain.AgentSummary{Summary: strings.Join(sentences, " ")}
+}
+
+func summarizeAvailability(propertyId int, startDate, endDate time.Time, availability domain.Availability) domain.AgentSummary {
+	stay := fmt.Sprintf("from %s to %s (%s)", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly),
+		plural(nights(startDate, endDate), "night", "nights"))
+	items := summarizeRoomTypes(availability)
+	if availability.RatePlans != nil {
+		if items == nil {
+			items = &[]domain.AgentSummaryItem{}
+		}
+		for _, quote := range *availability.RatePlans {
+			*items = append(*items, domain.AgentSummaryItem{
+				Id:   quote.RatePlanId,
+				Text: fmt.Sprintf("%s: %.2f in total. %s", quote.Name, quote.Price, describeCancellation(quote.Cancellation)),
+			})
+		}
+	}
+	if !availability.Available {
+		summary := fmt.Sprintf("Property %d is not available %s.", propertyId, stay)
+		if availability.Violations != nil {
+			for _, violation := range *availability.Violations {
+				summary += " " + violation.Message
+			}
+		}
+		return domain.AgentSummary{Summary: summary, Items: items}
+	}
+	var discount string
+	if availability.Discount != nil {
+		discount = fmt.Sprintf(" The promo code takes %.2f off.", *availability.Discount)
+	}
+	if items != nil {
+		return domain.AgentSummary{Summary: fmt.Sprintf("Property %d is available %s from %.2f in total.%s",
+			propertyId, stay, availability.Price, discount), Items: items}
+	}
+	return domain.AgentSummary{Summary: fmt.Sprintf("Property %d is available %s for %.2f in total.%s",
+		propertyId, stay, availability.Price, discount)}
+}
+
+// summarizeRoomTypes returns a sentence per room type, nil for a property
+// booked as a whole.
+func summarizeRoomTypes(availability domain.Availability) *[]domain.AgentSummaryItem {
+	if availability.RoomTypes == nil {
+		return nil
+	}
+	items := make([]domain.AgentSummaryItem, 0, len(*availability.RoomTypes))
+	for _, roomType := range *availability.RoomTypes {
+		text := roomType.Name + ": not available."
+		if roomType.Available {
+			text = fmt.Sprintf("%s: %s left, %.2f in total.", roomType.Name,
+				plural(roomType.UnitsAvailable, "unit", "units"), roomType.Price)
+		}
+		items = append(items, domain.AgentSummaryItem{Id: roomType.RoomTypeId, Text: text})
+	}
+	return &items
+}
+
+func summarizeBooking(booking domain.BookingResponse) domain.AgentSummary {
+	summary := fmt.Sprintf("Booking %s is confirmed for %s at property %d from %s to %s, %.2f in total.",
+		booking.BookingId, booking.CustomerName, booking.PropertyId,
+		booking.StartDate.Format(time.DateOnly), booking.EndDate.Format(time.DateOnly), booking.TotalAmount)
+	if booking.RoomTypeId != nil && booking.Unit != nil {
+		summary += fmt.Sprintf(" Room type %s, unit %d.", *booking.RoomTypeId, *booking.Unit)
+	}
+	if booking.RatePlanId != nil && booking.Cancellation != nil {
+		summary += fmt.Sprintf(" Rate plan %s. %s", *booking.RatePlanId, describeCancellation(*booking.Cancellation))
+	}
+	if booking.PromoCode != nil && booking.Discount != nil {
+		summary += fmt.Sprintf(" Promo code %s, %.2f off.", *booking.PromoCode, *booking.Discount)
+	}
+	if booking.CheckInInstructions != nil && *booking.CheckInInstructions != "" {
+		summary += " " + fullStop(*booking.CheckInInstructions)
+	}
+	return domain.AgentSummary{Summary: summary}
+}
+
+// describeProperty sums the property up in a sentence, e.g. 2 bedrooms for
+// up to 4 guests, 55 m², in Krakow, Poland: Old Town.
+func describeProperty(property domain.Property) string {
+	description := fmt.Sprintf("%s for up to %s, %d m², in %s, %s",
+		plural(property.Bedrooms, "bedroom", "bedrooms"), plural(property.Guests, "guest", "guests"),
+		property.Size, property.City, property.Country)
+	if property.Location != "" {
+		description += ": " + property.Location
+	}
+	return fullStop(description)
+}
+
+// describeCancellation tells until when the booking may be cancelled, in the
+// local time of the property.
+func describeCancellation(terms domain.CancellationTerms) string {
+	if !terms.Refundable || terms.CancellableUntil == nil {
+		return "It cannot be cancelled."
+	}
+	return fmt.Sprintf("Free cancellation until %s.", terms.CancellableUntil.Format("15:04 on Monday 2006-01-02"))
+}
+
+func plural(count int, singular, plural string) string {
+	if count == 1 {
+		return "1 " + singular
+	}
+	return strconv.Itoa(count) + " " + plural
+}
+
+func nights(startDate, endDate time.Time) int {
+	return int(endDate.Sub(startDate).Hours() / 24)
+}
+
+// fullStop ends the text with a full stop, unless it already has one.
+func fullStop(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasSuffix(text, ".") || strings.HasSuffix(text, "!") || strings.HasSuffix(text, "?") {
+		return text
+	}
+	return text + "."
+}
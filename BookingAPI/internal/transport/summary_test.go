@@ -0,0 +1,136 @@
+package transport
+
+import (
+	"booking/internal/domain"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func TestSummarizeSearch(t *testing.T) {
+	city := "Krakow"
+	properties := []domain.Property{
+		{PropertyId: 1, City: "Krakow", Country: "Poland", Location: "Old Town", Size: 55, Bedrooms: 2, Guests: 4},
+		{PropertyId: 2, City: "Krakow", Country: "Poland", Size: 30, Bedrooms: 1, Guests: 1},
+	}
+
+	summary := summarizeSearch(domain.SearchOptions{City: &city}, properties)
+	if summary.Summary != "Found 2 properties in Krakow." {
+		t.Errorf("summarizeSearch() summary = %q", summary.Summary)
+	}
+	want := []domain.AgentSummaryItem{
+		{Id: "1", Text: "2 bedrooms for up to 4 guests, 55 m², in Krakow, Poland: Old Town."},
+		{Id: "2", Text: "1 bedroom for up to 1 guest, 30 m², in Krakow, Poland."},
+	}
+	for i, item := range *summary.Items {
+		if item != want[i] {
+			t.Errorf("summarizeSearch() item %d = %+v, want %+v", i, item, want[i])
+		}
+	}
+
+	if summary := summarizeSearch(domain.SearchOptions{}, nil); summary.Summary != "No property matches the search." {
+		t.Errorf("summarizeSearch() summary = %q", summary.Summary)
+	}
+}
+
+func TestSummarizeAvailability(t *testing.T) {
+	start := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	discount := float32(27.5)
+
+	for _, test := range []struct {
+		availability domain.Availability
+		want         string
+	}{
+		{domain.Availability{Available: true, Price: 110},
+			"Property 1 is available from 2024-07-01 to 2024-07-03 (2 nights) for 110.00 in total."},
+		{domain.Availability{Available: true, Price: 82.5, Discount: &discount},
+			"Property 1 is available from 2024-07-01 to 2024-07-03 (2 nights) for 82.50 in total. " +
+				"The promo code takes 27.50 off."},
+		{domain.Availability{},
+			"Property 1 is not available from 2024-07-01 to 2024-07-03 (2 nights)."},
+		{domain.Availability{Violations: &[]domain.RuleViolation{
+			{Rule: domain.MinNights, Field: "endDate", Message: "Stays at the property are at least 3 nights long."},
+		}}, "Property 1 is not available from 2024-07-01 to 2024-07-03 (2 nights). " +
+			"Stays at the property are at least 3 nights long."},
+	} {
+		summary := summarizeAvailability(1, start, start.AddDate(0, 0, 2), test.availability)
+		if summary.Summary != test.want {
+			t.Errorf("summarizeAvailability() = %q, want %q", summary.Summary, test.want)
+		}
+	}
+}
+
+func TestSummarizeAvailabilityRoomTypes(t *testing.T) {
+	start := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	availability := domain.Availability{Available: true, Price: 88, RoomTypes: &[]domain.RoomTypeAvailability{
+		{RoomTypeId: "double", Name: "Double room", Available: true, UnitsAvailable: 2, Price: 88},
+		{RoomTypeId: "family-suite", Name: "Family suite", Price: 160},
+	}}
+
+	summary := summarizeAvailability(4, start, start.AddDate(0, 0, 2), availability)
+	if want := "Property 4 is available from 2024-07-01 to 2024-07-03 (2 nights) from 88.00 in total."; summary.Summary != want {
+		t.Errorf("summarizeAvailability() = %q, want %q", summary.Summary, want)
+	}
+	want := []domain.AgentSummaryItem{
+		{Id: "double", Text: "Double room: 2 units left, 88.00 in total."},
+		{Id: "family-suite", Text: "Family suite: not available."},
+	}
+	if summary.Items == nil || !slices.Equal(*summary.Items, want) {
+		t.Errorf("summarizeAvailability().Items = %+v, want %+v", summary.Items, want)
+	}
+}
+
+func TestSummarizeBookingPromoCode(t *testing.T) {
+	start := time.Date(2024, time.July, 10, 0, 0, 0, 0, time.UTC)
+	code, discount := "SUMMER24", float32(16.5)
+
+	summary := summarizeBooking(domain.BookingResponse{
+		CustomerName: "John Doe",
+		PropertyId:   1,
+		StartDate:    openapi_types.Date{Time: start},
+		EndDate:      openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+		TotalAmount:  93.5,
+		PromoCode:    &code,
+		Discount:     &discount,
+	})
+	want := "93.50 in total. Promo code SUMMER24, 16.50 off."
+	if !strings.HasSuffix(summary.Summary, want) {
+		t.Errorf("summarizeBooking() = %q, want it ending with %q", summary.Summary, want)
+	}
+}
+
+func TestSummarizeBookingRatePlan(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, time.July, 10, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, time.July, 8, 14, 0, 0, 0, warsaw)
+	flexible, nonRefundable := "flexible", "non-refundable"
+
+	for _, test := range []struct {
+		ratePlanId   *string
+		cancellation domain.CancellationTerms
+		want         string
+	}{
+		{&flexible, domain.CancellationTerms{Refundable: true, CancellableUntil: &until},
+			" Rate plan flexible. Free cancellation until 14:00 on Monday 2024-07-08."},
+		{&nonRefundable, domain.CancellationTerms{}, " Rate plan non-refundable. It cannot be cancelled."},
+	} {
+		summary := summarizeBooking(domain.BookingResponse{
+			CustomerName: "John Doe",
+			PropertyId:   4,
+			StartDate:    openapi_types.Date{Time: start},
+			EndDate:      openapi_types.Date{Time: start.AddDate(0, 0, 2)},
+			TotalAmount:  88,
+			RatePlanId:   test.ratePlanId,
+			Cancellation: &test.cancellation,
+		})
+		if !strings.HasSuffix(summary.Summary, test.want) {
+			t.Errorf("summarizeBooking() = %q, want it ending with %q", summary.Summary, test.want)
+		}
+	}
+}
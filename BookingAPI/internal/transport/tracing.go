@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HeaderTraceID carries the X-Ray trace the request belongs to.
+const HeaderTraceID = "X-Amzn-Trace-Id"
+
+// Tracing starts the server span of the request, continuing the trace of
+// the X-Amzn-Trace-Id header. The span is named after the method until the
+// request is routed to an operation, see nameSpan. The trace ID is added to
+// the request logger.
+func Tracing() Middleware {
+	tracer := otel.Tracer("booking/transport")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+				))
+			defer span.End()
+
+			if span.SpanContext().IsValid() {
+				logging.Add(ctx, "trace_id", span.SpanContext().TraceID().String())
+			}
+
+			writer := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(writer, r.WithContext(ctx))
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(writer.status))
+			if writer.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(writer.status))
+			}
+		})
+	}
+}
+
+// nameSpan names the span of the request after the operation it is routed
+// to, e.g. BookProperty.
+func nameSpan(f domain.StrictHandlerFunc, operationID string) domain.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		trace.SpanFromContext(ctx).SetName(operationID)
+		return f(ctx, w, r, request)
+	}
+}
+
+// statusWriter captures the status of the response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
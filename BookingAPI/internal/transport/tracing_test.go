@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"booking/internal/database/memory"
+	"booking/internal/domain"
+	"booking/internal/service/properties"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	xrayTraceHeader = "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"
+	xrayTraceID     = "5759e988bd862e3fe1be46a994272793"
+	xrayParentID    = "53995c3f42cd8ad8"
+)
+
+// useExporter registers a tracer provider exporting the spans to memory and
+// the X-Ray propagator, as tracing.Setup does, until the test is over.
+func useExporter(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(xray.Propagator{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	return exporter
+}
+
+func TestTracing(t *testing.T) {
+	exporter := useExporter(t)
+	handler := Tracing()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/properties/1", nil)
+	request.Header.Set(HeaderTraceID, xrayTraceHeader)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("spans = %d, want 1", len(spans))
+	}
+	span := spans[0]
+	if traceID := span.SpanContext.TraceID().String(); traceID != xrayTraceID {
+		t.Errorf("trace ID = %s, want %s", traceID, xrayTraceID)
+	}
+	if parentID := span.Parent.SpanID().String(); parentID != xrayParentID || !span.Parent.IsRemote() {
+		t.Errorf("parent = %s, want the remote %s", parentID, xrayParentID)
+	}
+	if span.Name != http.MethodGet || span.Status.Code != codes.Error {
+		t.Errorf("span = %s %v, want GET failed", span.Name, span.Status)
+	}
+}
+
+func TestHandlerTrace(t *testing.T) {
+	exporter := useExporter(t)
+	store := memory.NewPropertiesStore(domain.Property{PropertyId: 1, City: "Krakow", Country: "Poland", Size: 55,
+		Bedrooms: 2, Guests: 4})
+	handler := NewHandler(NewServer(Services{Properties: properties.NewService(store, nil)}), Tracing())
+
+	// the Lambda runtime passes the trace of the invocation in the context
+	ctx := context.WithValue(context.Background(), "x-amzn-trace-id", xrayTraceHeader)
+	response, err := handler(ctx, Request{HTTPMethod: http.MethodGet, Path: "/properties/1"})
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("handler() = %v, %v, want 200", response, err)
+	}
+
+	var server *tracetest.SpanStub
+	spans := exporter.GetSpans()
+	for i := range spans {
+		if spans[i].Parent.IsRemote() {
+			server = &spans[i]
+		}
+	}
+	if server == nil {
+		t.Fatalf("spans = %v, want the server span continuing the invocation", spans)
+	}
+	if traceID := server.SpanContext.TraceID().String(); traceID != xrayTraceID || server.Name != "GetProperty" {
+		t.Errorf("span = %s %s, want GetProperty in %s", server.Name, traceID, xrayTraceID)
+	}
+}
@@ -0,0 +1,165 @@
+package transport
+
+import (
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// ValidationMode controls what happens when a request or a response does not
+// conform to the embedded API specification.
+type ValidationMode string
+
+const (
+	// ValidationOff skips the validation entirely.
+	ValidationOff ValidationMode = "off"
+	// ValidationLog only logs the violations, it is meant for production.
+	ValidationLog ValidationMode = "log"
+	// ValidationStrict rejects invalid requests with 400 and replaces invalid
+	// responses with 500, it is meant for tests and local runs.
+	ValidationStrict ValidationMode = "strict"
+)
+
+func init() {
+	// the summaries are JSON under a media type of their own
+	openapi3filter.RegisterBodyDecoder(ContentTypeAgent, openapi3filter.RegisteredBodyDecoder(ContentTypeJSON))
+}
+
+// Validation checks incoming requests and outgoing responses against the
+// API specification embedded in the domain package. API Gateway performs the
+// request validation only when deployed, this makes it happen everywhere.
+func Validation(mode ValidationMode) Middleware {
+	if mode == ValidationOff {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	router := mustNewSpecRouter()
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				// unknown routes and methods are reported by the router
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			err = openapi3filter.ValidateRequest(r.Context(), input)
+			if err != nil {
+				fields := violations(err)
+				logging.FromContext(r.Context()).Warn("invalid request",
+					"method", r.Method, "path", r.URL.Path, "violations", violationsMessage(fields))
+				if mode == ValidationStrict {
+					writeProblem(w, r, problemInvalidRequest.problem(
+						"The request does not conform to the API specification.", fields))
+					return
+				}
+			}
+
+			recorder := newResponseWriter()
+			next.ServeHTTP(recorder, r)
+
+			err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 recorder.statusCode(),
+				Header:                 recorder.header,
+				Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+				Options:                options,
+			})
+			if err != nil {
+				logging.FromContext(r.Context()).Warn("invalid response",
+					"method", r.Method, "path", r.URL.Path, "violations", violationsMessage(violations(err)))
+				if mode == ValidationStrict {
+					writeProblem(w, r, problemInternal.problem("", nil))
+					return
+				}
+			}
+
+			recorder.writeTo(w)
+		})
+	}
+}
+
+func mustNewSpecRouter() routers.Router {
+	spec, err := domain.GetSwagger()
+	if err != nil {
+		panic(fmt.Errorf("unable to load embedded API specification: %w", err))
+	}
+	// the routes are matched by path only, whichever stage serves them
+	spec.Servers = nil
+
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		panic(fmt.Errorf("unable to route embedded API specification: %w", err))
+	}
+	return router
+}
+
+// violations flattens the errors reported by the validation into one entry
+// per offending field, leaving out the schema dumps.
+func violations(err error) []domain.FieldError {
+	var result []domain.FieldError
+	collectViolations(err, "", &result)
+	return result
+}
+
+func collectViolations(err error, field string, result *[]domain.FieldError) {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, inner := range e {
+			collectViolations(inner, field, result)
+		}
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			field = e.Parameter.Name
+		}
+		if e.Err != nil {
+			collectViolations(e.Err, field, result)
+			return
+		}
+		*result = append(*result, domain.FieldError{Field: field, Message: e.Reason})
+	case *openapi3filter.ResponseError:
+		if e.Err != nil {
+			collectViolations(e.Err, field, result)
+			return
+		}
+		*result = append(*result, domain.FieldError{Field: field, Message: e.Reason})
+	case *openapi3.SchemaError:
+		path := append([]string{}, e.JSONPointer()...)
+		if field != "" {
+			path = append([]string{field}, path...)
+		}
+		*result = append(*result, domain.FieldError{Field: strings.Join(path, "."), Message: e.Reason})
+	default:
+		*result = append(*result, domain.FieldError{Field: field, Message: err.Error()})
+	}
+}
+
+func violationsMessage(fields []domain.FieldError) string {
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Field + ": " + field.Message
+	}
+	return strings.Join(messages, "; ")
+}
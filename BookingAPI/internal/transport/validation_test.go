@@ -0,0 +1,85 @@
+package transport
+
+import (
+	"booking/internal/domain"
+	"booking/internal/logging"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const validProperty = `{"propertyId": 1, "address": "123 Elm St", "city": "Krakow", "country": "Poland",
+	"location": "Old Town", "size": 55, "bedrooms": 2, "guests": 4}`
+
+func TestValidation(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		mode       ValidationMode
+		path, body string
+		status     int
+		called     bool
+		logged     string
+	}{
+		{"valid", ValidationStrict, "/properties/1", validProperty, http.StatusOK, true, ""},
+		{"invalid request strict", ValidationStrict, "/properties/0", validProperty, http.StatusBadRequest, false,
+			"invalid request"},
+		{"invalid request logged", ValidationLog, "/properties/0", validProperty, http.StatusOK, true,
+			"invalid request"},
+		{"invalid response strict", ValidationStrict, "/properties/1", `{"propertyId": 1}`,
+			http.StatusInternalServerError, true, "invalid response"},
+		{"invalid response logged", ValidationLog, "/properties/1", `{"propertyId": 1}`, http.StatusOK, true,
+			"invalid response"},
+		{"off", ValidationOff, "/properties/0", `{"propertyId": 1}`, http.StatusOK, true, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			handler := Validation(tc.mode)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.Header().Set("Content-Type", ContentTypeJSON)
+				w.Write([]byte(tc.body))
+			}))
+
+			var logs bytes.Buffer
+			request := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			request = request.WithContext(logging.NewContext(request.Context(),
+				slog.New(slog.NewJSONHandler(&logs, nil))))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tc.status {
+				t.Errorf("status = %d, want %d", recorder.Code, tc.status)
+			}
+			if called != tc.called {
+				t.Errorf("called = %v, want %v", called, tc.called)
+			}
+			if tc.logged == "" && logs.Len() > 0 || !strings.Contains(logs.String(), tc.logged) {
+				t.Errorf("logs = %q, want %q", logs.String(), tc.logged)
+			}
+			if tc.status == http.StatusOK {
+				if recorder.Body.String() != tc.body {
+					t.Errorf("body = %q, want the response of the handler", recorder.Body.String())
+				}
+				return
+			}
+
+			if contentType := recorder.Header().Get("Content-Type"); contentType != ContentTypeProblem {
+				t.Errorf("content type = %q, want %q", contentType, ContentTypeProblem)
+			}
+			var problem domain.Problem
+			if err := json.NewDecoder(recorder.Body).Decode(&problem); err != nil {
+				t.Fatalf("unable to decode the problem: %v", err)
+			}
+			if problem.Status != tc.status {
+				t.Errorf("problem status = %d, want %d", problem.Status, tc.status)
+			}
+			if tc.status == http.StatusBadRequest && (problem.Errors == nil ||
+				(*problem.Errors)[0].Field != "propertyId") {
+				t.Errorf("problem errors = %+v, want the propertyId", problem.Errors)
+			}
+		})
+	}
+}
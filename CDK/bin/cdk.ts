@@ -17,6 +17,10 @@ const embeddingModelArn =
 
 const app = new cdk.App();
 
+// the AgentFunctionArn output of the Booking API stack, deployed in the
+// region of the agent, e.g. cdk deploy --all -c agentFunctionArn=arn:aws:lambda:...
+const agentFunctionArn: string = app.node.getContext("agentFunctionArn");
+
 function getStackId(stackName: string) {
   return `${stackName}-GenAISimplified-${environment.account}-${environment.region}`;
 }
@@ -38,6 +42,8 @@ const knowledgeBaseStack = new KnowledgeBaseStack(app, getStackId("KnowledgeBase
 
 const actionGroupStack = new ActionGroupStack(app, getStackId("ActionGroupStack"), {
   env: environment,
+  agentFunctionArn: agentFunctionArn,
+  useFunctionSchema: app.node.tryGetContext("actionGroupSchema") === "functions",
 });
 
 new BedrockAgentStack(app, getStackId("BedrockAgentStack"), {
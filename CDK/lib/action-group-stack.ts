@@ -1,17 +1,25 @@
-import { Duration, Stack, StackProps } from "aws-cdk-lib";
+import { Stack, StackProps } from "aws-cdk-lib";
 import { Construct } from "constructs";
 import { CfnAgent } from "aws-cdk-lib/aws-bedrock";
 import { Bucket } from "aws-cdk-lib/aws-s3";
 import { BucketDeployment, Source } from "aws-cdk-lib/aws-s3-deployment";
-import { NodejsFunction } from "aws-cdk-lib/aws-lambda-nodejs";
-import { Runtime } from "aws-cdk-lib/aws-lambda";
-import { ServicePrincipal } from "aws-cdk-lib/aws-iam";
+import { readFileSync } from "fs";
+
+export interface ActionGroupStackProps extends StackProps {
+  // ARN of the agent function of the Booking API, the AgentFunctionArn
+  // output of its SAM stack
+  agentFunctionArn: string;
+  // defines the action group with the function details generated by
+  // make agent-functions instead of the OpenAPI schema, which needs the
+  // quota of parameters per function raised above its default of 5
+  useFunctionSchema?: boolean;
+}
 
 export class ActionGroupStack extends Stack {
     readonly actionGroupProperties: CfnAgent.AgentActionGroupProperty;
     readonly actionGroupSchemaArn: string;
 
-    constructor(scope: Construct, id: string, props: StackProps) {
+    constructor(scope: Construct, id: string, props: ActionGroupStackProps) {
         super(scope, id, props)
     
     const bucket = new Bucket(this, "AgentBucket");
@@ -26,36 +34,25 @@ export class ActionGroupStack extends Stack {
     const s3ObjectKey = "api.yaml";
     this.actionGroupSchemaArn = `arn:aws:s3:::${s3BucketName}/${s3ObjectKey}`
 
-    const actionGroupLambda = new NodejsFunction(
-        this,
-        "actionGroupLambda",
-        {
-          entry: "./lib/bookingApi/index.ts",
-          handler: "handler",
-          runtime: Runtime.NODEJS_18_X,
-          timeout: Duration.minutes(15),
-        }
-      );
-
-      actionGroupLambda.addPermission('BedrockPermission', {
-        principal: new ServicePrincipal('bedrock.amazonaws.com'),
-        action: 'lambda:InvokeFunction'
-        });
-
-
+      // the function serves the operations in-process, it is deployed and
+      // allowed to be invoked by Bedrock along with the Booking API
       this.actionGroupProperties = {
         actionGroupName: 'Booking',
         actionGroupExecutor: {
-          lambda: actionGroupLambda.functionArn
-        },
-        apiSchema: {
-          s3: {
-            s3BucketName: s3BucketName,
-            s3ObjectKey: s3ObjectKey,
-          }
+          lambda: props.agentFunctionArn
         },
+        ...(props.useFunctionSchema ? {
+          functionSchema: JSON.parse(readFileSync("./lib/bookingApi/schema/functions.json", "utf-8")),
+        } : {
+          apiSchema: {
+            s3: {
+              s3BucketName: s3BucketName,
+              s3ObjectKey: s3ObjectKey,
+            }
+          },
+        }),
         description: "This is an API that allows to search for properties and rooms, book them, check availability and cancel bookings." +
         "Call to it should not be made untill all params are collected.",
       };
     }
-}
\ No newline at end of file
+}